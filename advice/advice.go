@@ -0,0 +1,120 @@
+package advice
+
+import (
+	"strings"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+// Item is a piece of advice. It is also the key of the advice phrases
+// of personas and languages, and the name of the pictogram in images.
+type Item string
+
+// Items of advice.
+const (
+	Umbrella        Item = "umbrella"
+	FoldingUmbrella Item = "folding_umbrella"
+	Coat            Item = "coat"
+	Layer           Item = "layer"
+	ShortSleeves    Item = "short_sleeves"
+	Laundry         Item = "laundry"
+	IndoorLaundry   Item = "indoor_laundry"
+)
+
+// Thresholds decide the advice.
+// Umbrella, FoldingUmbrella and Laundry are probabilities of precipitation
+// in percent, Coat, ShortSleeves and Layer are temperatures in Celsius.
+type Thresholds struct {
+	// Umbrella is the probability from which an umbrella is needed.
+	Umbrella int `json:"umbrella"`
+	// FoldingUmbrella is the probability from which a folding umbrella is
+	// advised. It is also advised when the weather mentions rain or snow.
+	FoldingUmbrella int `json:"folding_umbrella"`
+	// Coat is the highest temperature below which a coat is needed.
+	Coat float64 `json:"coat"`
+	// ShortSleeves is the highest temperature from which short sleeves are enough.
+	ShortSleeves float64 `json:"short_sleeves"`
+	// Layer is the difference between the highest and lowest temperatures
+	// from which a layer to take off is advised.
+	Layer float64 `json:"layer"`
+	// Laundry is the probability of the morning and afternoon up to which
+	// laundry dries outside. Laundry is inside from FoldingUmbrella.
+	Laundry int `json:"laundry"`
+}
+
+// DefaultThresholds are the thresholds used when the config does not give them.
+var DefaultThresholds = Thresholds{
+	Umbrella:        50,
+	FoldingUmbrella: 30,
+	Coat:            12,
+	ShortSleeves:    25,
+	Layer:           10,
+	Laundry:         20,
+}
+
+// Day is what the advice is derived from.
+// Weather is the weather text, e.g. 晴れ時々くもり, and POP is
+// the probabilities of precipitation of the four periods, -1 when unknown.
+type Day struct {
+	Weather string
+	POP     [4]int
+	Low     temp.Temp
+	High    temp.Temp
+}
+
+// wet reports whether the weather mentions precipitation.
+func (d Day) wet() bool {
+	for _, s := range []string{"雨", "雪", "みぞれ", "雷"} {
+		if strings.Contains(d.Weather, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxPOP returns the highest probability of the periods, -1 when unknown.
+func maxPOP(pop []int) int {
+	max := -1
+	for _, p := range pop {
+		if p > max {
+			max = p
+		}
+	}
+	return max
+}
+
+// Advise returns the advice of the day in the order of umbrella,
+// clothing and laundry. Each of them is left out when there is
+// nothing to say or it is unknown.
+func (t Thresholds) Advise(d Day) []Item {
+	var items []Item
+
+	pop := maxPOP(d.POP[:])
+	switch {
+	case pop >= t.Umbrella:
+		items = append(items, Umbrella)
+	case pop >= t.FoldingUmbrella || d.wet():
+		items = append(items, FoldingUmbrella)
+	}
+
+	if d.High.Valid() {
+		high := d.High.In(temp.Celsius)
+		switch {
+		case high < t.Coat:
+			items = append(items, Coat)
+		case d.Low.Valid() && high-d.Low.In(temp.Celsius) >= t.Layer:
+			items = append(items, Layer)
+		case high >= t.ShortSleeves:
+			items = append(items, ShortSleeves)
+		}
+	}
+
+	daytime := maxPOP(d.POP[1:3])
+	switch {
+	case daytime >= t.FoldingUmbrella || d.wet():
+		items = append(items, IndoorLaundry)
+	case daytime >= 0 && daytime <= t.Laundry:
+		items = append(items, Laundry)
+	}
+	return items
+}
@@ -0,0 +1,67 @@
+package advice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func c(v float64) temp.Temp { return temp.New(v, temp.Celsius) }
+
+func TestAdvise(t *testing.T) {
+	unknown := [4]int{-1, -1, -1, -1}
+	tests := []struct {
+		name string
+		day  Day
+		want []Item
+	}{
+		// umbrella by the highest probability of the day
+		{"umbrella at the threshold", Day{Weather: "くもり", POP: [4]int{0, 50, 0, 0}}, []Item{Umbrella, IndoorLaundry}},
+		{"folding umbrella below umbrella", Day{Weather: "くもり", POP: [4]int{49, 0, 0, 0}}, []Item{FoldingUmbrella, Laundry}},
+		{"folding umbrella at the threshold", Day{Weather: "くもり", POP: [4]int{0, 0, 0, 30}}, []Item{FoldingUmbrella, Laundry}},
+		{"no umbrella below folding umbrella", Day{Weather: "くもり", POP: [4]int{29, 0, 20, 29}}, []Item{Laundry}},
+		{"folding umbrella for rain in the weather", Day{Weather: "晴れ時々雨", POP: [4]int{0, 0, 0, 0}}, []Item{FoldingUmbrella, IndoorLaundry}},
+		{"folding umbrella for snow", Day{Weather: "くもり一時雪", POP: unknown}, []Item{FoldingUmbrella, IndoorLaundry}},
+		{"nothing when unknown", Day{Weather: "くもり", POP: unknown}, nil},
+
+		// clothing by the highest and lowest temperatures
+		{"coat below the threshold", Day{POP: unknown, High: c(11.9), Low: c(0)}, []Item{Coat}},
+		{"layers at the coat threshold", Day{POP: unknown, High: c(12), Low: c(2)}, []Item{Layer}},
+		{"no layers under the difference", Day{POP: unknown, High: c(20), Low: c(10.1)}, nil},
+		{"layers at the difference", Day{POP: unknown, High: c(20), Low: c(10)}, []Item{Layer}},
+		{"short sleeves at the threshold", Day{POP: unknown, High: c(25), Low: c(20)}, []Item{ShortSleeves}},
+		{"layers before short sleeves", Day{POP: unknown, High: c(30), Low: c(18)}, []Item{Layer}},
+		{"short sleeves without the lowest", Day{POP: unknown, High: c(25)}, []Item{ShortSleeves}},
+		{"nothing without the highest", Day{POP: unknown, Low: c(0)}, nil},
+		{"fahrenheit is compared in celsius", Day{POP: unknown, High: temp.New(53, temp.Fahrenheit), Low: temp.New(50, temp.Fahrenheit)}, []Item{Coat}},
+
+		// laundry by the morning and afternoon
+		{"laundry at the threshold", Day{POP: [4]int{90, 20, 10, 90}}, []Item{Umbrella, Laundry}},
+		{"no laundry advice between", Day{POP: [4]int{0, 21, 29, 0}}, nil},
+		{"indoor laundry at folding umbrella", Day{POP: [4]int{0, 0, 30, 0}}, []Item{FoldingUmbrella, IndoorLaundry}},
+		{"laundry with a known period", Day{POP: [4]int{-1, -1, 0, -1}}, []Item{Laundry}},
+	}
+	for _, tt := range tests {
+		if got := DefaultThresholds.Advise(tt.day); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Advise() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAdviseThresholds(t *testing.T) {
+	th := Thresholds{Umbrella: 80, FoldingUmbrella: 60, Coat: 5, ShortSleeves: 30, Layer: 15, Laundry: 40}
+	tests := []struct {
+		day  Day
+		want []Item
+	}{
+		{Day{POP: [4]int{0, 70, 0, 0}, High: c(12), Low: c(8)}, []Item{FoldingUmbrella, IndoorLaundry}},
+		{Day{POP: [4]int{0, 40, 0, 80}, High: c(29), Low: c(20)}, []Item{Umbrella, Laundry}},
+		{Day{POP: [4]int{0, 50, 0, 0}, High: c(4), Low: c(-2)}, []Item{Coat}},
+	}
+	for _, tt := range tests {
+		if got := th.Advise(tt.day); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Advise(%+v) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
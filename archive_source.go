@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiveSource reads reports archived by Fetcher.
+// It is used to replay a past run.
+type ArchiveSource struct {
+	Store  Store
+	Office string
+}
+
+func (s *ArchiveSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	var links []string
+	for day := sday; !day.After(eday.Add(24 * time.Hour)); day = day.Add(24 * time.Hour) {
+		keys, err := s.Store.List("archive/report/" + day.Format("20060102") + "/")
+		if err != nil {
+			return nil, fmt.Errorf("ArchiveSource:list error:%v", err)
+		}
+
+		for _, key := range keys {
+			var r Report
+			if err := s.FetchReport(ctx, key, &r); err != nil {
+				log.Println(err)
+				continue
+			}
+			if r.Control.Title != title || r.Control.PublishingOffice != s.Office {
+				continue
+			}
+			tt, err := time.Parse(time.RFC3339, r.Head.ReportDateTime)
+			if err != nil {
+				return nil, fmt.Errorf("ArchiveSource:parse error:%v", err)
+			}
+			if tt.Before(eday) && tt.After(sday) {
+				links = append(links, key)
+			}
+		}
+	}
+	if len(links) == 0 {
+		return nil, errors.Wrap(errLinkNotFound, "ArchiveSource")
+	}
+	return links, nil
+}
+
+func (s *ArchiveSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	data, err := s.Store.Get(link)
+	if err != nil {
+		return fmt.Errorf("ArchiveSource:get error:%v", err)
+	}
+
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return fmt.Errorf("ArchiveSource:decode error:%v", err)
+	}
+	return nil
+}
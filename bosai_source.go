@@ -0,0 +1,379 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bamchoh/bam-weather/weathercode"
+	"github.com/pkg/errors"
+)
+
+const bosaiForecastURL = "https://www.jma.go.jp/bosai/forecast/data/forecast/"
+
+// BosaiForecast is an element of the JSON of the JMA forecast API.
+// The first one is the daily forecast and the second one is the weekly forecast.
+type BosaiForecast struct {
+	PublishingOffice string            `json:"publishingOffice"`
+	ReportDatetime   string            `json:"reportDatetime"`
+	TimeSeries       []BosaiTimeSeries `json:"timeSeries"`
+}
+
+type BosaiTimeSeries struct {
+	TimeDefines []string    `json:"timeDefines"`
+	Areas       []BosaiArea `json:"areas"`
+}
+
+type BosaiArea struct {
+	Area          Area     `json:"area"`
+	WeatherCodes  []string `json:"weatherCodes"`
+	Weathers      []string `json:"weathers"`
+	Winds         []string `json:"winds"`
+	Waves         []string `json:"waves"`
+	Pops          []string `json:"pops"`
+	Reliabilities []string `json:"reliabilities"`
+	Temps         []string `json:"temps"`
+	TempsMin      []string `json:"tempsMin"`
+	TempsMax      []string `json:"tempsMax"`
+}
+
+// BosaiSource reads the forecast of the JMA forecast API
+// (/bosai/forecast/data/forecast/<OfficeCode>.json), which only has
+// the latest 府県天気予報 and 府県週間天気予報. They are converted into
+// the same Report and WeeklyReport as the XML.
+type BosaiSource struct {
+	OfficeCode string
+	Fetcher    *Fetcher
+
+	forecasts []BosaiForecast
+}
+
+// bosai links are the URL of the JSON with the title as the fragment.
+func (s *BosaiSource) url() string {
+	return bosaiForecastURL + s.OfficeCode + ".json"
+}
+
+func (s *BosaiSource) load(ctx context.Context) ([]BosaiForecast, error) {
+	if s.forecasts != nil {
+		return s.forecasts, nil
+	}
+	var forecasts []BosaiForecast
+	if err := s.Fetcher.FetchJSON(ctx, s.url(), &forecasts); err != nil {
+		return nil, fmt.Errorf("BosaiSource:%v", err)
+	}
+	if len(forecasts) < 2 {
+		return nil, fmt.Errorf("BosaiSource:unexpected forecast length %d", len(forecasts))
+	}
+	s.forecasts = forecasts
+	return forecasts, nil
+}
+
+func (s *BosaiSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	var idx int
+	switch title {
+	case "府県天気予報":
+		idx = 0
+	case "府県週間天気予報":
+		idx = 1
+	default:
+		return nil, errors.Wrap(errLinkNotFound, "BosaiSource")
+	}
+
+	forecasts, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tt, err := time.Parse(time.RFC3339, forecasts[idx].ReportDatetime)
+	if err != nil {
+		return nil, fmt.Errorf("BosaiSource:parse error:%v", err)
+	}
+	if !tt.After(sday) || !tt.Before(eday) {
+		return nil, errors.Wrap(errLinkNotFound, "BosaiSource")
+	}
+	return []string{s.url() + "#" + title}, nil
+}
+
+func (s *BosaiSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	forecasts, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	title := link[strings.LastIndex(link, "#")+1:]
+	var f BosaiForecast
+	switch title {
+	case "府県天気予報":
+		f = forecasts[0]
+	case "府県週間天気予報":
+		f = forecasts[1]
+	default:
+		return fmt.Errorf("BosaiSource:unknown link %v", link)
+	}
+
+	control := Control{
+		Title:            title,
+		DateTime:         f.ReportDatetime,
+		Status:           "通常",
+		EditorialOffice:  f.PublishingOffice,
+		PublishingOffice: f.PublishingOffice,
+	}
+	head := Head{
+		ReportDateTime: f.ReportDatetime,
+		EventID:        s.OfficeCode,
+		InfoType:       "発表",
+		InfoKind:       title,
+	}
+
+	switch v := v.(type) {
+	case *reportHeader:
+		v.Control, v.Head = control, head
+	case *Report:
+		v.Control, v.Head = control, head
+		v.Body, err = bosaiDailyBody(f)
+	case *WeeklyReport:
+		v.Control, v.Head = control, head
+		v.Body, err = bosaiWeeklyBody(f)
+	default:
+		return fmt.Errorf("BosaiSource:%T is not supported", v)
+	}
+	if err != nil {
+		return fmt.Errorf("BosaiSource:%v", err)
+	}
+	return nil
+}
+
+func bosaiTimeDefines(defs []string) []TimeDefine {
+	var tds []TimeDefine
+	for i, dt := range defs {
+		tds = append(tds, TimeDefine{ID: strconv.Itoa(i + 1), DateTime: dt})
+	}
+	return tds
+}
+
+// bosaiDayName returns the name of the day of tt from the report time rt.
+func bosaiDayName(rt, tt time.Time) string {
+	rday := time.Date(rt.Year(), rt.Month(), rt.Day(), 0, 0, 0, 0, rt.Location())
+	tday := time.Date(tt.Year(), tt.Month(), tt.Day(), 0, 0, 0, 0, rt.Location())
+	switch int(tday.Sub(rday).Hours() / 24) {
+	case 0:
+		return "今日"
+	case 1:
+		return "明日"
+	case 2:
+		return "明後日"
+	}
+	return ""
+}
+
+func bosaiDailyBody(f BosaiForecast) (Body, error) {
+	var body Body
+	if len(f.TimeSeries) < 3 {
+		return body, fmt.Errorf("unexpected time series length %d", len(f.TimeSeries))
+	}
+	rt, err := time.Parse(time.RFC3339, f.ReportDatetime)
+	if err != nil {
+		return body, err
+	}
+
+	// 区域予報: weather, wind and wave of the days, and 6-hour POPs
+	area := MeteorologicalInfo{Type: "区域予報"}
+	days := TimeSeriesInfo{TimeDefines: bosaiTimeDefines(f.TimeSeries[0].TimeDefines)}
+	for i := range days.TimeDefines {
+		tt, err := time.Parse(time.RFC3339, days.TimeDefines[i].DateTime)
+		if err != nil {
+			return body, err
+		}
+		days.TimeDefines[i].Name = bosaiDayName(rt, tt)
+	}
+	for _, a := range f.TimeSeries[0].Areas {
+		weather := Property{Type: "天気"}
+		wind := Property{Type: "風"}
+		wave := Property{Type: "波"}
+		for _, def := range days.TimeDefines {
+			i, _ := strconv.Atoi(def.ID)
+			if w := bosaiValue(a.Weathers, i-1); w != "" {
+				part := parseWeatherSentence(w)
+				part.ID = def.ID
+				weather.WeatherForecasts = append(weather.WeatherForecasts, part)
+				weather.Weathers = append(weather.Weathers, RefValue{ID: def.ID, Value: w})
+			}
+			if c := bosaiValue(a.WeatherCodes, i-1); c != "" {
+				weather.WeatherCodes = append(weather.WeatherCodes, RefValue{ID: def.ID, Value: c})
+			}
+			if w := bosaiValue(a.Winds, i-1); w != "" {
+				wind.WindForecasts = append(wind.WindForecasts, WindForecastPart{ID: def.ID, Sentence: w})
+			}
+			if w := bosaiValue(a.Waves, i-1); w != "" {
+				wave.WaveForecasts = append(wave.WaveForecasts, WaveHeightForecastPart{ID: def.ID, Sentence: w})
+			}
+		}
+		days.Items = append(days.Items, Item{Area: a.Area, Kinds: []Property{weather, wind, wave}})
+	}
+	area.TimeSeriesInfos = append(area.TimeSeriesInfos, days)
+
+	pops := TimeSeriesInfo{TimeDefines: bosaiTimeDefines(f.TimeSeries[1].TimeDefines)}
+	for _, a := range f.TimeSeries[1].Areas {
+		pop := Property{Type: "降水確率"}
+		for i, p := range a.Pops {
+			pop.POPs = append(pop.POPs, RefValue{ID: strconv.Itoa(i + 1), Value: p})
+		}
+		pops.Items = append(pops.Items, Item{Area: a.Area, Kinds: []Property{pop}})
+	}
+	area.TimeSeriesInfos = append(area.TimeSeriesInfos, pops)
+
+	// 地点予報: the lowest is at 00:00 and the highest is at 09:00 of the day
+	station := MeteorologicalInfo{Type: "地点予報"}
+	temps := TimeSeriesInfo{TimeDefines: bosaiTimeDefines(f.TimeSeries[2].TimeDefines)}
+	for i := range temps.TimeDefines {
+		tt, err := time.Parse(time.RFC3339, temps.TimeDefines[i].DateTime)
+		if err != nil {
+			return body, err
+		}
+		if tt.Hour() < 9 {
+			temps.TimeDefines[i].Name = bosaiDayName(rt, tt) + "朝"
+		} else {
+			temps.TimeDefines[i].Name = bosaiDayName(rt, tt) + "日中"
+		}
+	}
+	for _, a := range f.TimeSeries[2].Areas {
+		item := Item{Station: Station{Name: a.Area.Name, Code: a.Area.Code}}
+		for _, def := range temps.TimeDefines {
+			i, _ := strconv.Atoi(def.ID)
+			typ := "最高気温"
+			if strings.HasSuffix(def.Name, "朝") {
+				typ = "最低気温"
+			}
+			t := Temperature{ID: def.ID, Type: typ, Unit: "度", Temp: bosaiValue(a.Temps, i-1)}
+			item.Kinds = append(item.Kinds, Property{Type: typ, TemperaturePart: TemperaturePart{Temperatures: []Temperature{t}}})
+		}
+		temps.Items = append(temps.Items, item)
+	}
+	station.TimeSeriesInfos = append(station.TimeSeriesInfos, temps)
+
+	body.MeteorologicalInfos = []MeteorologicalInfo{area, station}
+	return body, nil
+}
+
+func bosaiWeeklyBody(f BosaiForecast) (WeeklyBody, error) {
+	var body WeeklyBody
+	if len(f.TimeSeries) < 2 {
+		return body, fmt.Errorf("unexpected time series length %d", len(f.TimeSeries))
+	}
+
+	var defs []WeeklyTimeDefine
+	for i, dt := range f.TimeSeries[0].TimeDefines {
+		defs = append(defs, WeeklyTimeDefine{ID: strconv.Itoa(i + 1), DateTime: dt})
+	}
+	area := WeeklyInfo{Type: "区域予報", TimeDefines: defs}
+	for _, a := range f.TimeSeries[0].Areas {
+		weather := WeeklyProperty{Type: "天気"}
+		pop := WeeklyProperty{Type: "降水確率"}
+		reliability := WeeklyProperty{Type: "信頼度"}
+		for i, def := range defs {
+			code := bosaiValue(a.WeatherCodes, i)
+			text := code
+			if t, ok := weathercode.Lookup(code); ok {
+				text = t.Text
+			}
+			weather.Weathers = append(weather.Weathers, RefValue{ID: def.ID, Value: text})
+			weather.WeatherCodes = append(weather.WeatherCodes, RefValue{ID: def.ID, Value: code})
+			pop.POPs = append(pop.POPs, RefValue{ID: def.ID, Value: bosaiValue(a.Pops, i)})
+			reliability.Reliabilities = append(reliability.Reliabilities, RefValue{ID: def.ID, Value: bosaiValue(a.Reliabilities, i)})
+		}
+		area.Items = append(area.Items, WeeklyItem{Area: a.Area, Kinds: []WeeklyProperty{weather, pop, reliability}})
+	}
+
+	defs = nil
+	for i, dt := range f.TimeSeries[1].TimeDefines {
+		defs = append(defs, WeeklyTimeDefine{ID: strconv.Itoa(i + 1), DateTime: dt})
+	}
+	station := WeeklyInfo{Type: "地点予報", TimeDefines: defs}
+	for _, a := range f.TimeSeries[1].Areas {
+		low := WeeklyProperty{Type: "最低気温"}
+		high := WeeklyProperty{Type: "最高気温"}
+		for i, def := range defs {
+			low.Temperatures = append(low.Temperatures, Temperature{ID: def.ID, Unit: "度", Temp: bosaiValue(a.TempsMin, i)})
+			high.Temperatures = append(high.Temperatures, Temperature{ID: def.ID, Unit: "度", Temp: bosaiValue(a.TempsMax, i)})
+		}
+		station.Items = append(station.Items, WeeklyItem{Station: Station{Name: a.Area.Name, Code: a.Area.Code}, Kinds: []WeeklyProperty{low, high}})
+	}
+
+	body.MeteorologicalInfos = []WeeklyInfo{area, station}
+	return body, nil
+}
+
+func bosaiValue(values []string, i int) string {
+	if i < 0 || i >= len(values) {
+		return ""
+	}
+	return strings.TrimSpace(values[i])
+}
+
+// parseWeatherSentence splits a weather sentence such as
+// "晴れ　夕方　から　くもり　所により　夜　雨　で　雷を伴う"
+// into the parts which the XML has.
+func parseWeatherSentence(s string) WeatherForecastPart {
+	part := WeatherForecastPart{Sentence: s}
+	words := strings.Fields(strings.Replace(s, "　", " ", -1))
+	if len(words) == 0 {
+		return part
+	}
+
+	for i, w := range words {
+		if w == "所により" || strings.HasSuffix(w, "では") {
+			// the place such as 山沿い comes before a separate では
+			if w == "では" && i > 1 {
+				i--
+			}
+			part.SubArea.Sentence = strings.Join(words[i:], "　")
+			words = words[:i]
+			break
+		}
+	}
+
+	part.Base.Weather = Weather{Type: "天気", Text: words[0]}
+	last := &part.Base
+	modifier := ""
+	joined := false
+	for _, w := range words[1:] {
+		switch {
+		case w == "で":
+			joined = true
+		case joined:
+			// e.g. 雨　で　雷を伴う is a weather
+			last.Weather.Text += "　で　" + w
+			joined = false
+		case w == "時々" || w == "一時":
+			modifier = w
+		case w == "後" || w == "のち":
+			modifier = "後"
+		case w == "から" || w == "まで":
+			modifier += w
+		case isWeatherWord(w):
+			info := WeatherInfo{TimeModifier: modifier, Weather: Weather{Type: "天気", Text: w}}
+			if modifier == "時々" || modifier == "一時" {
+				part.Temporary = append(part.Temporary, info)
+				last = &part.Temporary[len(part.Temporary)-1]
+			} else {
+				part.Becoming = append(part.Becoming, info)
+				last = &part.Becoming[len(part.Becoming)-1]
+			}
+			modifier = ""
+		default:
+			// time such as 夕方 or 夜 followed by から
+			modifier += w
+		}
+	}
+	return part
+}
+
+func isWeatherWord(w string) bool {
+	for _, s := range []string{"晴れ", "くもり", "雨", "雪", "雷", "霧", "みぞれ"} {
+		if strings.HasPrefix(w, s) {
+			return true
+		}
+	}
+	return false
+}
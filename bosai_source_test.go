@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseWeatherSentence(t *testing.T) {
+	tests := []struct {
+		in   string
+		want WeatherForecastPart
+	}{
+		{
+			"晴れ",
+			WeatherForecastPart{
+				Base: WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+			},
+		},
+		{
+			"晴れ　夕方　から　くもり　所により　夜　雨　で　雷を伴う",
+			WeatherForecastPart{
+				Base:     WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+				Becoming: []WeatherInfo{{TimeModifier: "夕方から", Weather: Weather{Type: "天気", Text: "くもり"}}},
+				SubArea:  SubArea{Sentence: "所により　夜　雨　で　雷を伴う"},
+			},
+		},
+		{
+			"晴れ　時々　くもり　山沿い　では　雨",
+			WeatherForecastPart{
+				Base:      WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+				Temporary: []WeatherInfo{{TimeModifier: "時々", Weather: Weather{Type: "天気", Text: "くもり"}}},
+				SubArea:   SubArea{Sentence: "山沿い　では　雨"},
+			},
+		},
+		{
+			"くもり　時々　雨　海上　では　雷を伴い　激しく　降る",
+			WeatherForecastPart{
+				Base:      WeatherInfo{Weather: Weather{Type: "天気", Text: "くもり"}},
+				Temporary: []WeatherInfo{{TimeModifier: "時々", Weather: Weather{Type: "天気", Text: "雨"}}},
+				SubArea:   SubArea{Sentence: "海上　では　雷を伴い　激しく　降る"},
+			},
+		},
+		{
+			"雨　後　くもり　北部では　明け方　まで　雪",
+			WeatherForecastPart{
+				Base:     WeatherInfo{Weather: Weather{Type: "天気", Text: "雨"}},
+				Becoming: []WeatherInfo{{TimeModifier: "後", Weather: Weather{Type: "天気", Text: "くもり"}}},
+				SubArea:  SubArea{Sentence: "北部では　明け方　まで　雪"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt.want.Sentence = tt.in
+		if got := parseWeatherSentence(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseWeatherSentence(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/bamchoh/bam-weather/dialect"
+)
+
+const (
+	channelTwitter  = "twitter"
+	channelMastodon = "mastodon"
+	channelSlack    = "slack"
+)
+
+// Channel is an output of the daily forecast and the persona used there.
+// When Language is other than ja, the forecast is translated instead.
+// Template is the key of a text/template in the store, which replaces
+// the default layout of the persona.
+// WebhookURL is the incoming webhook of the slack channel.
+type Channel struct {
+	Type       string `json:"type"`
+	Persona    string `json:"persona"`
+	Language   string `json:"language"`
+	Template   string `json:"template"`
+	WebhookURL string `json:"webhook_url"`
+	template   *template.Template
+}
+
+func (c Channel) check() error {
+	switch c.Type {
+	case channelTwitter, channelMastodon:
+	case channelSlack:
+		if c.WebhookURL == "" {
+			return fmt.Errorf("slack channel needs webhook_url")
+		}
+	default:
+		return fmt.Errorf("channel type (%v) is not supported", c.Type)
+	}
+	_, translated, err := lookupLang(c.Language)
+	if err != nil {
+		return err
+	}
+	if _, ok := personas[c.Persona]; !ok && !translated {
+		return fmt.Errorf("persona (%v) was not found", c.Persona)
+	}
+	return nil
+}
+
+// forecastText returns the text of f for the channel.
+func (c Channel) forecastText(f Forecast) string {
+	return c.renderForecast(f, false)
+}
+
+// renderForecast returns the text of f for the channel,
+// without the hashtag when noTag is set.
+func (c Channel) renderForecast(f Forecast, noTag bool) string {
+	if l, ok, _ := lookupLang(c.Language); ok {
+		if noTag {
+			nl := *l
+			nl.Phrases.Tag = ""
+			l = &nl
+		}
+		return translateForecast(f, l)
+	}
+
+	p := personas[c.Persona]
+	if noTag {
+		np := *p
+		np.Phrases.Tag = ""
+		p = &np
+	}
+	if c.template != nil {
+		text, err := executeForecastTemplate(c.template, f, p)
+		if err == nil {
+			return text
+		}
+		log.Println(err)
+	}
+	return generateForecast(f, p)
+}
+
+// phrases returns the phrases of the persona of the channel.
+// The weekly and warning phrases which a persona file leaves out are
+// those of the standard persona.
+func (c Channel) phrases() dialect.Phrases {
+	p := personas[c.Persona].Phrases
+	std := dialect.Personas()[dialect.Standard].Phrases
+	if p.WeeklyIntro == "" {
+		p.WeeklyIntro = std.WeeklyIntro
+	}
+	if p.WarningIntro == "" {
+		p.WarningIntro, p.WarningIssued, p.WarningUpgraded = std.WarningIntro, std.WarningIssued, std.WarningUpgraded
+		p.WarningDowngraded, p.WarningCancelled = std.WarningDowngraded, std.WarningCancelled
+	}
+	return p
+}
+
+// correctedPrefix returns the heading of a corrected forecast.
+func (c Channel) correctedPrefix() string {
+	if l, ok, _ := lookupLang(c.Language); ok {
+		return l.Phrases.Corrected
+	}
+	return "【訂正】"
+}
+
+// cancelledText returns the text telling the forecast for region was cancelled.
+func (c Channel) cancelledText(region Region) string {
+	format, tag := "", ""
+	if l, ok, _ := lookupLang(c.Language); ok {
+		format, tag = l.Phrases.Cancelled, l.Phrases.Tag
+		region.Name = region.NameIn(l.Code)
+	} else {
+		p := personas[c.Persona]
+		format, tag = "【取消】"+p.Phrases.Cancelled, p.Phrases.Tag
+	}
+	lines := []string{fmt.Sprintf(format, region.Name)}
+	if tag != "" {
+		lines = append(lines, tag)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// media is an image attached to posts with its alt text.
+// Media without data are skipped.
+type media struct {
+	data []byte
+	alt  string
+}
+
+// post posts texts to the channel as a thread.
+// Images are attached to the first post, slack posts no images.
+func (c Channel) post(texts []string, images ...media) error {
+	switch c.Type {
+	case channelTwitter:
+		return tweetThread(texts, images...)
+	case channelMastodon:
+		return tootThread(texts, images...)
+	case channelSlack:
+		return postSlack(c.WebhookURL, strings.Join(texts, "\n"))
+	}
+	return fmt.Errorf("channel type (%v) is not supported", c.Type)
+}
+
+// postChannels posts the texts made for each channel.
+// A failed channel is logged and the others are still posted,
+// the error names the failed channels.
+func postChannels(cfg *Config, text func(c Channel) []string, images ...media) error {
+	var failed []string
+	for _, c := range cfg.Channels {
+		texts := text(c)
+		for _, t := range texts {
+			log.Printf("Text (%s): %s\n", c.Type, t)
+		}
+		if err := c.post(texts, images...); err != nil {
+			log.Println(err)
+			failed = append(failed, c.Type)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to post to %v", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// recordChannel returns the first channel, whose text is kept in the post record.
+func recordChannel(cfg *Config) Channel {
+	if len(cfg.Channels) > 0 {
+		return cfg.Channels[0]
+	}
+	return Channel{Type: channelTwitter, Persona: dialect.Osaka}
+}
+
+func postSlack(webhookURL, text string) error {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: defaultFetchTimeout}
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &statusError{URL: "slack webhook", Status: resp.StatusCode}
+	}
+	return nil
+}
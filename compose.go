@@ -0,0 +1,170 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+// platform is the length limit of a post and how the length is measured.
+type platform struct {
+	limit  int
+	length func(s string) int
+}
+
+// platforms are the limits of the channel types. Slack has none.
+var platforms = map[string]platform{
+	channelTwitter:  {limit: 280, length: twitterLength},
+	channelMastodon: {limit: 500, length: mastodonLength},
+}
+
+var urlPattern = regexp.MustCompile(`https?://\S+`)
+
+// urlLength is the length of a URL on twitter and mastodon.
+const urlLength = 23
+
+// twitterLength returns the weighted length of s on twitter.
+// Latin and some punctuation count 1 and the others (CJK, emoji) count 2,
+// a URL counts 23.
+func twitterLength(s string) int {
+	n := 0
+	s = urlPattern.ReplaceAllStringFunc(s, func(string) string {
+		n += urlLength
+		return ""
+	})
+	for _, r := range s {
+		switch {
+		case r <= 0x10ff,
+			r >= 0x2000 && r <= 0x200d,
+			r >= 0x2010 && r <= 0x201f,
+			r >= 0x2032 && r <= 0x2037:
+			n++
+		default:
+			n += 2
+		}
+	}
+	return n
+}
+
+// mastodonLength returns the length of s on mastodon,
+// where a character counts 1 and a URL counts 23.
+func mastodonLength(s string) int {
+	n := 0
+	s = urlPattern.ReplaceAllStringFunc(s, func(string) string {
+		n += urlLength
+		return ""
+	})
+	return n + utf8.RuneCountInString(s)
+}
+
+func (p platform) fits(s string) bool {
+	return p.length(s) <= p.limit
+}
+
+// spaces separate words, including the full-width space of JMA reports.
+const spaces = " 　"
+
+var wordPattern = regexp.MustCompile(`[^ 　]+[ 　]*`)
+
+// split returns s in posts within the limit.
+// Lines are kept together, a line over the limit is cut between words,
+// and a word over the limit is cut by characters. URLs are never cut.
+func (p platform) split(s string) []string {
+	var posts []string
+	cur := ""
+	for _, line := range strings.Split(s, "\n") {
+		next := line
+		if cur != "" {
+			next = cur + "\n" + line
+		}
+		if p.fits(next) {
+			cur = next
+			continue
+		}
+		if cur != "" {
+			posts = append(posts, cur)
+		}
+		cur = ""
+		for _, word := range wordPattern.FindAllString(line, -1) {
+			if p.fits(strings.TrimRight(cur+word, spaces)) {
+				cur += word
+				continue
+			}
+			if cur = strings.TrimRight(cur, spaces); cur != "" {
+				posts = append(posts, cur)
+			}
+			cur = word
+			for !p.fits(strings.TrimRight(cur, spaces)) {
+				n := p.cut(cur)
+				posts = append(posts, cur[:n])
+				cur = cur[n:]
+			}
+		}
+		cur = strings.TrimRight(cur, spaces)
+	}
+	if cur != "" {
+		posts = append(posts, cur)
+	}
+	return posts
+}
+
+// cut returns the length in bytes of the longest head of word within
+// the limit, which is at least a character. A URL in word is left whole
+// for the next post.
+func (p platform) cut(word string) int {
+	n := 0
+	for i, r := range word {
+		if i > 0 && !p.fits(word[:i+utf8.RuneLen(r)]) {
+			break
+		}
+		n = i + utf8.RuneLen(r)
+	}
+	for _, loc := range urlPattern.FindAllStringIndex(word, -1) {
+		if loc[0] > 0 && loc[0] < n && n < loc[1] {
+			n = loc[0]
+		}
+	}
+	return n
+}
+
+// composeForecast returns the posts of f with prefix for the channel.
+// When the text is over the limit of the platform, the sub-area sentence,
+// the hashtag and the comparisons with the day before are dropped in
+// this order, and the text is split into a thread when it is still over.
+func (c Channel) composeForecast(f Forecast, prefix string) []string {
+	text := prefix + c.forecastText(f)
+	p, ok := platforms[c.Type]
+	if !ok {
+		return []string{text}
+	}
+
+	noTag := false
+	cuts := []func(){
+		func() { f.Weather.SubArea.Sentence = "" },
+		func() { noTag = true },
+		func() { f.Temps.PrevLow, f.Temps.PrevHigh = temp.Temp{}, temp.Temp{} },
+	}
+	for _, cut := range cuts {
+		if p.fits(text) {
+			return []string{text}
+		}
+		cut()
+		text = prefix + c.renderForecast(f, noTag)
+	}
+	if p.fits(text) {
+		return []string{text}
+	}
+	return p.split(text)
+}
+
+// compose returns text in posts within the limit of the platform,
+// split into a thread when it is over.
+func (c Channel) compose(text string) []string {
+	p, ok := platforms[c.Type]
+	if !ok || p.fits(text) {
+		return []string{text}
+	}
+	return p.split(text)
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func TestTwitterLength(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc 123", 7},
+		{"晴れ", 4},
+		{"☀☔", 4},
+		{"“a”", 3},
+		{"https://example.com/a/very/long/path/to/index.html?1599696000", 23},
+		{"大阪 https://example.com/index.html", 4 + 1 + 23},
+		{"a https://x.jp b https://y.jp", 1 + 1 + 23 + 3 + 23},
+	}
+	for _, tt := range tests {
+		if got := twitterLength(tt.s); got != tt.want {
+			t.Errorf("twitterLength(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestMastodonLength(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"abc", 3},
+		{"晴れ☀", 3},
+		{"大阪 https://example.com/a/very/long/path/to/index.html", 3 + 23},
+	}
+	for _, tt := range tests {
+		if got := mastodonLength(tt.s); got != tt.want {
+			t.Errorf("mastodonLength(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	p := platform{limit: 30, length: mastodonLength}
+	url := "https://example.com/a/very/long/path/index.html"
+	tests := []struct {
+		s    string
+		want []string
+	}{
+		{"short", []string{"short"}},
+		{
+			"first line of the text\nsecond line of it",
+			[]string{"first line of the text", "second line of it"},
+		},
+		{
+			"aaa\nbbb\nccc",
+			[]string{"aaa\nbbb\nccc"},
+		},
+		{
+			"the weather is sunny and then cloudy in the evening",
+			[]string{"the weather is sunny and then", "cloudy in the evening"},
+		},
+		{
+			"晴れ　夕方　から　くもり　所により　夜　雨　で　雷を伴う　海上　では　霧",
+			[]string{"晴れ　夕方　から　くもり　所により　夜　雨　で　雷を伴う", "海上　では　霧"},
+		},
+		{
+			"see the page at " + url,
+			[]string{"see the page at", url},
+		},
+		{
+			"abcdefghijklmnopqrstuvwxyz0123456789",
+			[]string{"abcdefghijklmnopqrstuvwxyz0123", "456789"},
+		},
+		{
+			"abcdefghijklmnopqrstuvwxyz" + url,
+			[]string{"abcdefghijklmnopqrstuvwxyz", url},
+		},
+	}
+	for _, tt := range tests {
+		got := p.split(tt.s)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("split(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+		for _, post := range got {
+			if !p.fits(post) {
+				t.Errorf("split(%q): %q is over the limit", tt.s, post)
+			}
+		}
+	}
+}
+
+func TestComposeForecast(t *testing.T) {
+	f := Forecast{
+		Region: regions["osaka"],
+		Date:   time.Date(2020, 9, 10, 0, 0, 0, 0, time.Local),
+		Weather: WeatherForecastPart{
+			Base:    WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+			SubArea: SubArea{Sentence: "所により　夜　雨　で　雷を伴う"},
+		},
+		Temps: DayTemps{
+			Low: temp.New(22, temp.Celsius), High: temp.New(31, temp.Celsius),
+			PrevLow: temp.New(21, temp.Celsius), PrevHigh: temp.New(33, temp.Celsius), PrevName: "昨日",
+		},
+		Unit: temp.Celsius,
+		POP:  [4]int{0, 0, 10, 40},
+		URL:  "https://example.com/index.html",
+	}
+	c := Channel{Type: "test", Persona: dialect.Standard}
+	full := c.forecastText(f)
+
+	noSubArea := f
+	noSubArea.Weather.SubArea.Sentence = ""
+	noCompare := noSubArea
+	noCompare.Temps.PrevLow, noCompare.Temps.PrevHigh = temp.Temp{}, temp.Temp{}
+	texts := []string{
+		full,
+		c.renderForecast(noSubArea, false),
+		c.renderForecast(noSubArea, true),
+		c.renderForecast(noCompare, true),
+	}
+	for i := 1; i < len(texts); i++ {
+		if mastodonLength(texts[i]) >= mastodonLength(texts[i-1]) {
+			t.Fatalf("cut %d does not shorten the text: %q", i, texts[i])
+		}
+	}
+
+	defer delete(platforms, c.Type)
+	for i, want := range texts {
+		platforms[c.Type] = platform{limit: mastodonLength(want), length: mastodonLength}
+		if got := c.composeForecast(f, ""); !reflect.DeepEqual(got, []string{want}) {
+			t.Errorf("cut %d: composeForecast() = %q, want %q", i, got, want)
+		}
+	}
+
+	// over the limit after all cuts, the shortest text is split into a thread
+	limit := mastodonLength(texts[len(texts)-1]) - 1
+	platforms[c.Type] = platform{limit: limit, length: mastodonLength}
+	got := c.composeForecast(f, "")
+	if len(got) < 2 {
+		t.Fatalf("composeForecast() = %q, want a thread", got)
+	}
+	if strings.Join(got, "\n") != texts[len(texts)-1] {
+		t.Errorf("thread %q is not made of %q", got, texts[len(texts)-1])
+	}
+
+	// the prefix is kept in the first post
+	platforms[c.Type] = platform{limit: 1000, length: mastodonLength}
+	if got := c.composeForecast(f, "【訂正】"); got[0] != "【訂正】"+full {
+		t.Errorf("composeForecast() with prefix = %q", got)
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/bamchoh/bam-weather/advice"
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/genpng"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/pkg/errors"
+)
+
+// Config is the bot configuration.
+// It is read from the JSON file given by the config field of the event.
+type Config struct {
+	Region  Region      `json:"region"`
+	Options Options     `json:"options"`
+	Store   StoreConfig `json:"store"`
+	// Source is the forecast source used when the event does not give one.
+	Source string `json:"source"`
+	// Failover reads the JMA forecast API when the regular_l feed
+	// has no report.
+	Failover bool `json:"failover"`
+	// Archive saves every fetched feed and report to the store.
+	Archive bool `json:"archive"`
+	// Personas are JSON files of personas by name,
+	// which add to or replace the built-in ones.
+	Personas map[string]string `json:"personas"`
+	// Themes are JSON files of image themes by name,
+	// which add to or replace the built-in ones.
+	Themes map[string]string `json:"themes"`
+	// Channels are where the daily forecast is posted.
+	Channels []Channel `json:"channels"`
+}
+
+// StoreConfig selects where outputs, states and archives are kept.
+// A local directory is used when Dir is set, otherwise the S3 bucket.
+type StoreConfig struct {
+	Dir    string `json:"dir"`
+	Bucket string `json:"bucket"`
+	Region string `json:"region"`
+}
+
+// Options switches optional parts of the forecast.
+type Options struct {
+	// Wind adds the wind forecast to the text.
+	Wind bool `json:"wind"`
+	// Wave adds the wave forecast to the text.
+	Wave bool `json:"wave"`
+	// WindImage adds the wind forecast to the image.
+	WindImage bool `json:"wind_image"`
+	// TempUnit is the unit of temperatures, "celsius" (default) or "fahrenheit".
+	TempUnit string `json:"temp_unit"`
+	// Language is the language of images and pages, "ja" (default), "en",
+	// "zh" or "ko". Labels the font cannot draw are in English.
+	Language string `json:"language"`
+	// Advice adds the umbrella, clothing and laundry advice to the text.
+	Advice bool `json:"advice"`
+	// AdviceImage adds the pictograms of the advice to the image.
+	AdviceImage bool `json:"advice_image"`
+	// AdviceThresholds decide the advice, the defaults are used for
+	// the fields it does not give.
+	AdviceThresholds advice.Thresholds `json:"advice_thresholds"`
+	// ImageThemes are the themes of the daily, weekly and hourly images,
+	// flat when they are not given.
+	ImageThemes map[string]string `json:"image_themes"`
+}
+
+// Unit returns the unit of temperatures.
+func (o Options) Unit() temp.Unit {
+	u, _ := temp.ParseUnit(o.TempUnit)
+	return u
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Region:  regions["osaka"].clone(),
+		Options: Options{AdviceThresholds: advice.DefaultThresholds},
+		Store: StoreConfig{
+			Bucket: "bam-weather",
+			Region: "ap-northeast-1",
+		},
+		Channels: []Channel{{Type: channelTwitter, Persona: dialect.Osaka}},
+		Archive:  true,
+		Failover: true,
+	}
+}
+
+func loadConfig(event SpecificTime) (*Config, error) {
+	cfg := defaultConfig()
+
+	if event.Config != "" {
+		f, err := os.Open(event.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open config")
+		}
+		defer f.Close()
+
+		// a region of the config is defined as a whole,
+		// without the names of the default one
+		def := cfg.Region
+		cfg.Region = Region{}
+		if err := json.NewDecoder(f).Decode(cfg); err != nil {
+			return nil, errors.Wrap(err, "failed to decode config")
+		}
+		if cfg.Region.OfficeCode == "" {
+			cfg.Region = def
+		}
+	}
+
+	if _, err := temp.ParseUnit(cfg.Options.TempUnit); err != nil {
+		return nil, errors.Wrap(err, "invalid temp_unit")
+	}
+
+	if _, _, err := lookupLang(cfg.Options.Language); err != nil {
+		return nil, errors.Wrap(err, "invalid language")
+	}
+
+	// the maps are made again for each run, so personas and themes of
+	// a config are not left for the next run on a warm start
+	loaded := dialect.Personas()
+	for name, file := range cfg.Personas {
+		p, err := dialect.LoadPersona(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load persona %s", name)
+		}
+		loaded[name] = p
+	}
+	personas = loaded
+
+	loadedThemes := genpng.Themes()
+	for name, file := range cfg.Themes {
+		t, err := genpng.LoadTheme(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load theme %s", name)
+		}
+		loadedThemes[name] = t
+	}
+	themes = loadedThemes
+
+	if err := checkImageThemes(cfg.Options); err != nil {
+		return nil, errors.Wrap(err, "invalid image_themes")
+	}
+
+	for _, c := range cfg.Channels {
+		if err := c.check(); err != nil {
+			return nil, errors.Wrap(err, "invalid channel")
+		}
+	}
+
+	if event.Region != "" {
+		r, err := lookupRegion(event.Region)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Region = r
+	}
+	return cfg, nil
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bamchoh/bam-weather/dialect"
+)
+
+func TestLoadConfigPerRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := *dialect.Personas()[dialect.Standard]
+	p.Name = "mine"
+	persona, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	personaFile := filepath.Join(dir, "mine.json")
+	themeFile := filepath.Join(dir, "theme.json")
+	configFile := filepath.Join(dir, "config.json")
+	files := map[string]string{
+		personaFile: string(persona),
+		themeFile:   `{"name": "mine", "default": {"top": "#000000", "bottom": "#ffffff"}}`,
+		configFile: `{"personas": {"mine": "` + filepath.ToSlash(personaFile) + `"},
+			"themes": {"mine": "` + filepath.ToSlash(themeFile) + `"}}`,
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := loadConfig(SpecificTime{Config: configFile}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := personas["mine"]; !ok {
+		t.Fatal("persona of the config is not loaded")
+	}
+	if _, ok := themes["mine"]; !ok {
+		t.Fatal("theme of the config is not loaded")
+	}
+	if _, err := loadConfig(SpecificTime{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := personas["mine"]; ok {
+		t.Error("persona of the previous config is left")
+	}
+	if _, ok := themes["mine"]; ok {
+		t.Error("theme of the previous config is left")
+	}
+}
+
+func TestLoadConfigRegion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kyoto := filepath.Join(dir, "kyoto.json")
+	custom := filepath.Join(dir, "custom.json")
+	files := map[string]string{
+		kyoto: `{"region": {"name": "京都", "publishing_office": "京都地方気象台", "office_code": "260000",
+			"area_code": "260010", "station": "京都", "names": {"en": "Kyoto"}}}`,
+		custom: `{"region": {"name": "奈良", "publishing_office": "奈良地方気象台", "office_code": "290000",
+			"area_code": "290010", "station": "奈良"}}`,
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(name, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		event SpecificTime
+		want  string
+	}{
+		{SpecificTime{Config: kyoto}, "Kyoto"},
+		{SpecificTime{}, "Osaka"},
+		{SpecificTime{Config: custom}, "奈良"},
+		{SpecificTime{Config: custom, Region: "tokyo"}, "Tokyo"},
+		{SpecificTime{}, "Osaka"},
+	}
+	for _, tt := range tests {
+		cfg, err := loadConfig(tt.event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := cfg.Region.NameIn("en"); got != tt.want {
+			t.Errorf("loadConfig(%+v).Region.NameIn(en) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+	if got := regions["osaka"].Names["en"]; got != "Osaka" {
+		t.Errorf("preset name was changed to %q", got)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const dailyPostKey = "posted/daily.json"
+
+// PostRecord is the daily forecast which was posted last time.
+type PostRecord struct {
+	BaseTime  time.Time `json:"base_time"`
+	Report    ReportRef `json:"report"`
+	Text      string    `json:"text"`
+	Cancelled bool      `json:"cancelled"`
+}
+
+func loadPostRecord(store Store) (*PostRecord, error) {
+	data, err := store.Get(dailyPostKey)
+	if err == errStoreNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load post record")
+	}
+
+	var rec PostRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, errors.Wrap(err, "failed to decode post record")
+	}
+	return &rec, nil
+}
+
+func savePostRecord(store Store, rec *PostRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return store.Put(dailyPostKey, "application/json", data)
+}
+
+// pinnedSource adds Ref to the reports found by Source when the window
+// includes its ReportDateTime, so that a correction published after
+// the window is still selected.
+type pinnedSource struct {
+	ForecastSource
+	Title string
+	Ref   ReportRef
+}
+
+func (s *pinnedSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	links, err := s.ForecastSource.FindReports(ctx, title, sday, eday)
+	if err != nil && errors.Cause(err) != errLinkNotFound {
+		return nil, err
+	}
+	if title != s.Title {
+		return links, err
+	}
+
+	tt, perr := time.Parse(time.RFC3339, s.Ref.ReportDateTime)
+	if perr != nil || !tt.After(sday) || !tt.Before(eday) {
+		return links, err
+	}
+	return append(links, s.Ref.Link), nil
+}
+
+// findCorrection returns the latest version of the report of rec
+// which was published after it. ok is false when there is none.
+func findCorrection(ctx context.Context, src ForecastSource, rec *PostRecord, tt time.Time) (ReportRef, bool, error) {
+	published, err := time.Parse(time.RFC3339, rec.Report.DateTime)
+	if err != nil {
+		return ReportRef{}, false, errors.Wrap(err, "failed to parse posted report time")
+	}
+	reported, err := time.Parse(time.RFC3339, rec.Report.ReportDateTime)
+	if err != nil {
+		return ReportRef{}, false, errors.Wrap(err, "failed to parse posted report time")
+	}
+
+	// feeds are searched by the published time and fixtures by the report time
+	sday := reported
+	if published.Before(sday) {
+		sday = published
+	}
+	refs, err := findReportRefs(ctx, src, "府県天気予報", sday.Add(-time.Minute), tt)
+	if err != nil {
+		if errors.Cause(err) == errLinkNotFound {
+			return ReportRef{}, false, nil
+		}
+		return ReportRef{}, false, err
+	}
+
+	latest := rec.Report
+	for _, ref := range refs {
+		if ref.sameReport(rec.Report) && ref.newerThan(latest) {
+			latest = ref
+		}
+	}
+	return latest, latest != rec.Report, nil
+}
+
+// forecastBody returns text without the link to index.html on the last line.
+func forecastBody(text string) string {
+	if i := strings.LastIndex(text, "\n"); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
+
+// runCorrections checks whether the report of the last daily post was
+// corrected (訂正) or cancelled (取消) and posts a follow-up.
+func runCorrections(ctx context.Context, cfg *Config, src ForecastSource, store Store, tt time.Time) error {
+	log.Println("Corrections")
+
+	rec, err := loadPostRecord(store)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if rec == nil || rec.Cancelled {
+		log.Println("no daily post to correct")
+		return nil
+	}
+
+	ref, ok, err := findCorrection(ctx, src, rec, tt)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if !ok {
+		log.Println("report was not corrected:", rec.Report.ReportDateTime)
+		return nil
+	}
+	log.Printf("report was updated (%s %s)\n", ref.InfoType, ref.DateTime)
+
+	if ref.InfoType == infoTypeCancelled {
+		postErr := postChannels(cfg, func(c Channel) []string {
+			return []string{c.cancelledText(cfg.Region)}
+		})
+
+		rec.Report = ref
+		rec.Cancelled = true
+		if err := savePostRecord(store, rec); err != nil {
+			log.Println(err)
+			return err
+		}
+		return postErr
+	}
+
+	gen := newDailyGenerator(cfg, &pinnedSource{
+		ForecastSource: src,
+		Title:          "府県天気予報",
+		Ref:            ref,
+	}, rec.BaseTime)
+	err = gen.Init(ctx)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	f := gen.Forecast()
+	text := recordChannel(cfg).forecastText(f)
+	if forecastBody(text) == forecastBody(rec.Text) {
+		log.Println("correction does not change the forecast")
+		rec.Report = gen.Report()
+		return savePostRecord(store, rec)
+	}
+
+	daily, err := publishDaily(store, "", gen, cfg, rec.BaseTime)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	hourly := publishHourly(store, "", gen, cfg)
+
+	postErr := postChannels(cfg, func(c Channel) []string {
+		return c.composeForecast(f, c.correctedPrefix())
+	}, daily, hourly)
+
+	rec.Report = gen.Report()
+	rec.Text = text
+	if err := savePostRecord(store, rec); err != nil {
+		log.Println(err)
+		return err
+	}
+	return postErr
+}
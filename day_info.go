@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// newDayInfo picks the forecast of refID for the region out of v.
+// highName is the name of the time define holding the highest temperature.
+func newDayInfo(v *Report, region Region, refID, highName string) (*DayInfo, error) {
+	di := DayInfo{POP: [4]int{-1, -1, -1, -1}}
+	var date time.Time
+	for _, info := range v.Body.MeteorologicalInfos {
+		if info.Type != "区域予報" || len(info.TimeSeriesInfos) == 0 {
+			continue
+		}
+		series := info.TimeSeriesInfos[0]
+		item, ok := findAreaItem(series.Items, region.AreaCode)
+		if !ok {
+			return nil, fmt.Errorf("area (%v) was not found", region.AreaCode)
+		}
+		for _, kind := range item.Kinds {
+			for _, w := range kind.WeatherForecasts {
+				if w.ID == refID {
+					di.Weather = w
+				}
+			}
+			if code := findRefValue(kind.WeatherCodes, refID); code != "" {
+				di.WeatherCode = code
+			}
+			for _, w := range kind.WindForecasts {
+				if w.ID == refID {
+					di.Wind = w.Sentence
+				}
+			}
+			for _, w := range kind.WaveForecasts {
+				if w.ID == refID {
+					di.Wave = w.Sentence
+				}
+			}
+		}
+
+		for _, def := range series.TimeDefines {
+			if def.ID != refID {
+				continue
+			}
+			day, err := time.Parse(time.RFC3339, def.DateTime)
+			if err != nil {
+				return nil, err
+			}
+			if err := setPOP(&di, info.TimeSeriesInfos[1:], region, day); err != nil {
+				return nil, err
+			}
+			date = day
+		}
+		break
+	}
+
+	for _, info := range v.Body.MeteorologicalInfos {
+		if info.Type != "地点予報" || len(info.TimeSeriesInfos) == 0 {
+			continue
+		}
+		series := info.TimeSeriesInfos[0]
+		item, ok := findStationItem(series.Items, region.Station)
+		if !ok {
+			return nil, fmt.Errorf("station (%v) was not found", region.Station)
+		}
+		for _, def := range series.TimeDefines {
+			var err error
+			switch def.Name {
+			case "明日朝":
+				di.TempL, err = findTemperature(item, def.ID).Value()
+			case highName:
+				di.TempH, err = findTemperature(item, def.ID).Value()
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		break
+	}
+
+	hourly, err := newHourlyForecasts(v, region, date)
+	if err != nil {
+		return nil, err
+	}
+	di.Hourly = hourly
+	return &di, nil
+}
+
+// setPOP sets the 6-hour probabilities of precipitation on the same date as day.
+func setPOP(di *DayInfo, seriesList []TimeSeriesInfo, region Region, day time.Time) error {
+	for _, series := range seriesList {
+		item, ok := findAreaItem(series.Items, region.AreaCode)
+		if !ok {
+			continue
+		}
+		for _, kind := range item.Kinds {
+			if kind.Type != "降水確率" {
+				continue
+			}
+			for _, def := range series.TimeDefines {
+				tt, err := time.Parse(time.RFC3339, def.DateTime)
+				if err != nil {
+					return err
+				}
+				if tt.Year() != day.Year() || tt.YearDay() != day.YearDay() {
+					continue
+				}
+				pop, err := strconv.Atoi(findRefValue(kind.POPs, def.ID))
+				if err != nil {
+					continue
+				}
+				di.POP[tt.Hour()/6] = pop
+			}
+		}
+	}
+	return nil
+}
+
+func findAreaItem(items []Item, code string) (Item, bool) {
+	for _, item := range items {
+		if item.Area.Code == code {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+func findStationItem(items []Item, name string) (Item, bool) {
+	for _, item := range items {
+		if item.Station.Name == name {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+func findTemperature(item Item, refID string) Temperature {
+	for _, kind := range item.Kinds {
+		for _, t := range kind.TemperaturePart.Temperatures {
+			if t.ID == refID {
+				return t
+			}
+		}
+	}
+	return Temperature{}
+}
@@ -0,0 +1,118 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Rule replaces From with To.
+// The rule is applied only when the text before From ends with one of After
+// (if any) and none of NotAfter, and the text after From starts with one of
+// Before (if any) and none of NotBefore. "^" in After and "$" in Before
+// match the start and the end of the text.
+// Examples are pairs of an input and its expected output, which are checked
+// by the tests for the built-in rules and when a persona file is read.
+type Rule struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	After     []string  `json:"after,omitempty"`
+	NotAfter  []string  `json:"not_after,omitempty"`
+	Before    []string  `json:"before,omitempty"`
+	NotBefore []string  `json:"not_before,omitempty"`
+	Examples  []Example `json:"examples"`
+}
+
+// Example is an input of a rule and its expected output.
+type Example struct {
+	In  string `json:"in"`
+	Out string `json:"out"`
+}
+
+// Rules is a set of replacement rules.
+// At each position the longest matching From is replaced, rules with the
+// same From are tried in order. Replaced text is not matched again,
+// so the order of rules with different From does not matter.
+type Rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+func endsWithAny(s string, suffixes []string) bool {
+	for _, suffix := range suffixes {
+		if suffix == "^" && s == "" || suffix != "^" && strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func startsWithAny(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix == "$" && s == "" || prefix != "$" && strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matches(before, rest string) bool {
+	if !strings.HasPrefix(rest, r.From) {
+		return false
+	}
+	after := rest[len(r.From):]
+	if len(r.After) > 0 && !endsWithAny(before, r.After) {
+		return false
+	}
+	if endsWithAny(before, r.NotAfter) {
+		return false
+	}
+	if len(r.Before) > 0 && !startsWithAny(after, r.Before) {
+		return false
+	}
+	if startsWithAny(after, r.NotBefore) {
+		return false
+	}
+	return true
+}
+
+// Apply returns s with the rules applied.
+func (rs *Rules) Apply(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		var hit *Rule
+		for j := range rs.Rules {
+			r := &rs.Rules[j]
+			if (hit == nil || len(r.From) > len(hit.From)) && r.matches(s[:i], s[i:]) {
+				hit = r
+			}
+		}
+		if hit != nil {
+			b.WriteString(hit.To)
+			i += len(hit.From)
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		b.WriteString(s[i : i+n])
+		i += n
+	}
+	return b.String()
+}
+
+// Check returns an error when a rule is empty, has no example,
+// or an example does not give the expected output.
+func (rs *Rules) Check() error {
+	for _, r := range rs.Rules {
+		if r.From == "" {
+			return fmt.Errorf("rule (to:%v) has empty from", r.To)
+		}
+		if len(r.Examples) == 0 {
+			return fmt.Errorf("rule (%v) has no examples", r.From)
+		}
+		for _, e := range r.Examples {
+			if out := rs.Apply(e.In); out != e.Out {
+				return fmt.Errorf("rule (%v): %v gives %v, expected %v", r.From, e.In, out, e.Out)
+			}
+		}
+	}
+	return nil
+}
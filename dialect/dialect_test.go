@@ -0,0 +1,89 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinPersonas(t *testing.T) {
+	for _, s := range builtinJSON {
+		if _, err := ReadPersona(strings.NewReader(s)); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, name := range []string{Standard, Osaka, Kyoto, Hakata, Keigo} {
+		if builtinPersonas[name] == nil {
+			t.Errorf("persona (%v) is not built in", name)
+		}
+	}
+}
+
+func TestBuiltinRules(t *testing.T) {
+	for name, p := range builtinPersonas {
+		for _, r := range p.Rules.Rules {
+			r := r
+			t.Run(name+"/"+r.From, func(t *testing.T) {
+				if len(r.Examples) == 0 {
+					t.Fatal("no examples")
+				}
+				for _, e := range r.Examples {
+					if out := p.Apply(e.In); out != e.Out {
+						t.Errorf("Apply(%q) = %q, want %q", e.In, out, e.Out)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		persona string
+		in      string
+		out     string
+	}{
+		// longest match: 午後 is not 午 + 後
+		{Osaka, "午後から雨", "午後から☔"},
+		{Standard, "午後から雨", "午後から雨"},
+		{Osaka, "晴れ後くもり", "☀からの☁"},
+		{Standard, "晴れ後くもり", "晴れのちくもり"},
+		// not_before: ではなく keeps では
+		{Osaka, "雨ではなく雪", "☔ではなく⛄"},
+		{Osaka, "海上では雨", "海のほうらへんは☔"},
+		// longer rules win over shorter ones at the same position
+		{Osaka, "非常に激しく降る", "めっちゃぎょーさん降る"},
+		{Osaka, "夜のはじめ頃から雨", "会社から退社する頃から☔"},
+		{Osaka, "晴れ　所により雨", "☀ どっかでは☔"},
+	}
+	for _, tt := range tests {
+		p := builtinPersonas[tt.persona]
+		if out := p.Apply(tt.in); out != tt.out {
+			t.Errorf("%s: Apply(%q) = %q, want %q", tt.persona, tt.in, out, tt.out)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		ok    bool
+	}{
+		{"valid", Rules{[]Rule{{From: "後", To: "のち", Examples: []Example{{"晴れ後雨", "晴れのち雨"}}}}}, true},
+		{"empty from", Rules{[]Rule{{To: "x", Examples: []Example{{"a", "a"}}}}}, false},
+		{"no examples", Rules{[]Rule{{From: "後", To: "のち"}}}, false},
+		{"wrong example", Rules{[]Rule{{From: "後", To: "のち", Examples: []Example{{"晴れ後雨", "晴れ後雨"}}}}}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.rules.Check(); (err == nil) != tt.ok {
+			t.Errorf("%s: Check() = %v", tt.name, err)
+		}
+	}
+}
+
+func TestReadPersonaError(t *testing.T) {
+	s := strings.Replace(osakaJSON, `"out": "☀からの☁"`, `"out": "☀のち☁"`, 1)
+	if _, err := ReadPersona(strings.NewReader(s)); err == nil {
+		t.Error("ReadPersona accepts a wrong example")
+	}
+}
@@ -0,0 +1,167 @@
+package dialect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bamchoh/bam-weather/advice"
+)
+
+// Names of the built-in personas.
+const (
+	Standard = "standard"
+	Osaka    = "osaka"
+	Kyoto    = "kyoto"
+	Hakata   = "hakata"
+	Keigo    = "keigo"
+)
+
+// Phrases are the sentence templates of a persona in fmt format.
+// Intro takes the region, the day and the weather sentence.
+// End closes a weather sentence and Topic follows a time modifier
+// such as 夕方から. Higher and Lower take the name of the compared day
+// and the difference, POP takes the period from which rain is likely
+// and the highest probability.
+// Tag is omitted when it is empty.
+// WeeklyIntro takes the region. WarningIntro takes the region,
+// WarningIssued and WarningCancelled take the name of a warning,
+// WarningUpgraded and WarningDowngraded take the name before and after.
+// Empty weekly and warning phrases are those of the standard persona.
+// Advice are the sentences of the advice items, an item without
+// a sentence is left out of the text.
+type Phrases struct {
+	Intro      string                 `json:"intro"`
+	End        string                 `json:"end"`
+	Topic      string                 `json:"topic"`
+	SubArea    string                 `json:"sub_area"`
+	Lowest     string                 `json:"lowest"`
+	Highest    string                 `json:"highest"`
+	HighestEnd string                 `json:"highest_end"`
+	Higher     string                 `json:"higher"`
+	Lower      string                 `json:"lower"`
+	Same       string                 `json:"same"`
+	Unknown    string                 `json:"unknown"`
+	BelowZero  string                 `json:"below_zero"`
+	POP        string                 `json:"pop"`
+	Wind       string                 `json:"wind"`
+	Wave       string                 `json:"wave"`
+	Tag        string                 `json:"tag"`
+	Cancelled  string                 `json:"cancelled"`
+	Advice     map[advice.Item]string `json:"advice"`
+
+	WeeklyIntro       string `json:"weekly_intro"`
+	WarningIntro      string `json:"warning_intro"`
+	WarningIssued     string `json:"warning_issued"`
+	WarningUpgraded   string `json:"warning_upgraded"`
+	WarningDowngraded string `json:"warning_downgraded"`
+	WarningCancelled  string `json:"warning_cancelled"`
+}
+
+// check returns an error when a template does not take its arguments.
+func (p Phrases) check() error {
+	formats := []struct {
+		name   string
+		format string
+		args   []interface{}
+	}{
+		{"intro", p.Intro, []interface{}{"", "", ""}},
+		{"sub_area", p.SubArea, []interface{}{""}},
+		{"lowest", p.Lowest, []interface{}{""}},
+		{"highest", p.Highest, []interface{}{""}},
+		{"higher", p.Higher, []interface{}{"", 1}},
+		{"lower", p.Lower, []interface{}{"", 1}},
+		{"same", p.Same, []interface{}{""}},
+		{"below_zero", p.BelowZero, []interface{}{1}},
+		{"pop", p.POP, []interface{}{"", 1}},
+		{"wind", p.Wind, []interface{}{""}},
+		{"wave", p.Wave, []interface{}{""}},
+		{"cancelled", p.Cancelled, []interface{}{""}},
+		{"weekly_intro", p.WeeklyIntro, []interface{}{""}},
+		{"warning_intro", p.WarningIntro, []interface{}{""}},
+		{"warning_issued", p.WarningIssued, []interface{}{""}},
+		{"warning_upgraded", p.WarningUpgraded, []interface{}{"", ""}},
+		{"warning_downgraded", p.WarningDowngraded, []interface{}{"", ""}},
+		{"warning_cancelled", p.WarningCancelled, []interface{}{""}},
+	}
+	for _, f := range formats {
+		if f.format == "" && (strings.HasPrefix(f.name, "weekly_") || strings.HasPrefix(f.name, "warning_")) {
+			continue
+		}
+		if f.format == "" {
+			return fmt.Errorf("phrase (%v) is empty", f.name)
+		}
+		if s := fmt.Sprintf(f.format, f.args...); strings.Contains(s, "%!") {
+			return fmt.Errorf("phrase (%v) is invalid: %v", f.name, s)
+		}
+	}
+	return nil
+}
+
+// Persona is a voice of the forecast text,
+// which is a set of rules for sentences of JMA reports and phrases around them.
+type Persona struct {
+	Name    string  `json:"name"`
+	Phrases Phrases `json:"phrases"`
+	Rules
+}
+
+func decodePersona(r io.Reader) (*Persona, error) {
+	p := &Persona{}
+	if err := json.NewDecoder(r).Decode(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ReadPersona decodes and checks the persona in JSON.
+func ReadPersona(r io.Reader) (*Persona, error) {
+	p, err := decodePersona(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Phrases.check(); err != nil {
+		return nil, fmt.Errorf("persona (%v): %v", p.Name, err)
+	}
+	if err := p.Check(); err != nil {
+		return nil, fmt.Errorf("persona (%v): %v", p.Name, err)
+	}
+	return p, nil
+}
+
+// LoadPersona reads the persona from the file.
+func LoadPersona(name string) (*Persona, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPersona(f)
+}
+
+// Personas returns the built-in personas by name.
+func Personas() map[string]*Persona {
+	m := map[string]*Persona{}
+	for name, p := range builtinPersonas {
+		m[name] = p
+	}
+	return m
+}
+
+// builtinPersonas are checked by the tests, so they are only decoded here.
+// A persona which cannot be decoded is a bug of the embedded data.
+var builtinPersonas = func() map[string]*Persona {
+	m := map[string]*Persona{}
+	for _, s := range builtinJSON {
+		p, err := decodePersona(strings.NewReader(s))
+		if err != nil {
+			panic(fmt.Sprintf("built-in persona: %v", err))
+		}
+		m[p.Name] = p
+	}
+	return m
+}()
+
+var builtinJSON = []string{standardJSON, osakaJSON, kyotoJSON, hakataJSON, keigoJSON}
@@ -0,0 +1,338 @@
+package dialect
+
+// The built-in personas. Each of them can be copied to a file and
+// overridden by personas in the config.
+
+const standardJSON = `{
+  "name": "standard",
+  "phrases": {
+    "intro": "%sの%sの天気は%s",
+    "end": "。",
+    "topic": "は",
+    "sub_area": "%sかもしれない",
+    "lowest": "最低気温は %s",
+    "highest": "最高気温は %s",
+    "highest_end": "",
+    "higher": "%sより%d度高い",
+    "lower": "%sより%d度低い",
+    "same": "%sと同じくらい",
+    "unknown": "不明",
+    "below_zero": "氷点下%d度",
+    "pop": "%sから雨の確率%d%%",
+    "wind": "風は%s",
+    "wave": "波は%s",
+    "tag": "#bam_weather",
+    "cancelled": "%sの天気予報は気象台が取り消した",
+    "weekly_intro": "%sの週間天気",
+    "warning_intro": "%sの警報・注意報",
+    "warning_issued": "%sが発表された",
+    "warning_upgraded": "%sが%sに引き上げられた",
+    "warning_downgraded": "%sは%sに引き下げられた",
+    "warning_cancelled": "%sは解除された",
+    "advice": {
+      "umbrella": "傘を持って出かけよう。",
+      "folding_umbrella": "折りたたみ傘があると安心。",
+      "coat": "コートが必要な寒さ。",
+      "layer": "脱ぎ着しやすい服がいい。",
+      "short_sleeves": "半袖で過ごせそう。",
+      "laundry": "洗濯物は外に干せそう。",
+      "indoor_laundry": "洗濯物は部屋干しがいい。"
+    }
+  },
+  "rules": [
+    {"from": "　", "to": " ",
+     "examples": [{"in": "晴れ　所により雨", "out": "晴れ 所により雨"}]},
+    {"from": "後", "to": "のち",
+     "examples": [{"in": "晴れ後くもり", "out": "晴れのちくもり"}]},
+    {"from": "午後", "to": "午後",
+     "examples": [{"in": "午後から雨", "out": "午後から雨"}]}
+  ]
+}
+`
+
+const osakaJSON = `{
+  "name": "osaka",
+  "phrases": {
+    "intro": "%sの%sの天気は基本%s",
+    "end": "や。",
+    "topic": "は",
+    "sub_area": "なんか%sらしいで",
+    "lowest": "いっちゃん低い温度は %s",
+    "highest": "いっちゃん高い温度は %s",
+    "highest_end": "やで",
+    "higher": "%sより%d度高いで",
+    "lower": "%sより%d度低いで",
+    "same": "%sと同じくらいやで",
+    "unknown": "わからへん",
+    "below_zero": "氷点下%d度",
+    "pop": "%sから雨の確率%d%%やで",
+    "wind": "風は%sや",
+    "wave": "波は%sや",
+    "tag": "#bam_weather",
+    "cancelled": "%sの天気予報は気象台が取り消したで",
+    "weekly_intro": "%sの週間天気やで",
+    "warning_intro": "%sの警報・注意報やで",
+    "warning_issued": "%sが出たで！",
+    "warning_upgraded": "%sが%sに上がったで！",
+    "warning_downgraded": "%sは%sに下がったで",
+    "warning_cancelled": "%sは解除されたで",
+    "advice": {
+      "umbrella": "傘持って行きや。",
+      "folding_umbrella": "折りたたみ傘持っとき。",
+      "coat": "コート着ていきや。",
+      "layer": "脱いだり着たりできる服にしとき。",
+      "short_sleeves": "半袖でいけるで。",
+      "laundry": "洗濯物は外に干せるで。",
+      "indoor_laundry": "洗濯物は部屋干しにしとき。"
+    }
+  },
+  "rules": [
+    {"from": "　", "to": " ",
+     "examples": [{"in": "晴れ　所により雨", "out": "☀ どっかでは☔"}]},
+    {"from": "後", "to": "からの",
+     "examples": [{"in": "晴れ後くもり", "out": "☀からの☁"}]},
+    {"from": "午後", "to": "午後",
+     "examples": [{"in": "午後から雨", "out": "午後から☔"}]},
+    {"from": "一時", "to": "ちょっとのま",
+     "examples": [{"in": "くもり一時雨", "out": "☁ちょっとのま☔"}]},
+    {"from": "を伴う", "to": "もある",
+     "examples": [{"in": "雨で雷を伴う", "out": "☔で⚡もある"}]},
+    {"from": "時々", "to": "たま～に",
+     "examples": [{"in": "晴れ時々くもり", "out": "☀たま～に☁"}]},
+    {"from": "を伴い", "to": "もあるし",
+     "examples": [{"in": "雷を伴い", "out": "⚡もあるし"}]},
+    {"from": "非常に", "to": "めっちゃ",
+     "examples": [{"in": "非常に激しく降る", "out": "めっちゃぎょーさん降る"}]},
+    {"from": "激しく", "to": "ぎょーさん",
+     "examples": [{"in": "激しく降る", "out": "ぎょーさん降る"}]},
+    {"from": "山地", "to": "山のほう",
+     "examples": [{"in": "山地では雪", "out": "山のほうらへんは⛄"}]},
+    {"from": "未明", "to": "夜おそぉに",
+     "examples": [{"in": "未明から雨", "out": "夜おそぉにから☔"}]},
+    {"from": "では", "to": "らへんは", "not_before": ["な"],
+     "examples": [
+       {"in": "海上では", "out": "海のほうらへんは"},
+       {"in": "雨ではなく雪", "out": "☔ではなく⛄"}
+     ]},
+    {"from": "所により", "to": "どっかでは",
+     "examples": [{"in": "所により雷", "out": "どっかでは⚡"}]},
+    {"from": "海上", "to": "海のほう",
+     "examples": [{"in": "海上", "out": "海のほう"}]},
+    {"from": "夜遅く", "to": "夜おそぉ",
+     "examples": [{"in": "夜遅くから", "out": "夜おそぉから"}]},
+    {"from": "夜のはじめ頃", "to": "会社から退社する頃",
+     "examples": [{"in": "夜のはじめ頃から雨", "out": "会社から退社する頃から☔"}]},
+    {"from": "晴れ", "to": "☀",
+     "examples": [{"in": "晴れ", "out": "☀"}]},
+    {"from": "雨", "to": "☔",
+     "examples": [{"in": "雨", "out": "☔"}]},
+    {"from": "雪", "to": "⛄",
+     "examples": [{"in": "雪", "out": "⛄"}]},
+    {"from": "くもり", "to": "☁",
+     "examples": [{"in": "くもり", "out": "☁"}]},
+    {"from": "雷", "to": "⚡",
+     "examples": [{"in": "雷", "out": "⚡"}]}
+  ]
+}
+`
+
+const kyotoJSON = `{
+  "name": "kyoto",
+  "phrases": {
+    "intro": "%sの%sのお天気は基本%s",
+    "end": "どす。",
+    "topic": "は",
+    "sub_area": "%sらしおす",
+    "lowest": "いちばん低い気温は %s",
+    "highest": "いちばん高い気温は %s",
+    "highest_end": "どす",
+    "higher": "%sより%d度高おす",
+    "lower": "%sより%d度低おす",
+    "same": "%sとおんなじくらいどす",
+    "unknown": "わからしまへん",
+    "below_zero": "氷点下%d度",
+    "pop": "%sから雨の確率%d%%どす",
+    "wind": "風は%sどす",
+    "wave": "波は%sどす",
+    "tag": "#bam_weather",
+    "cancelled": "%sの天気予報は気象台が取り消さはったえ",
+    "weekly_intro": "%sの週間天気どす",
+    "warning_intro": "%sの警報・注意報どす",
+    "warning_issued": "%sが出たえ！",
+    "warning_upgraded": "%sが%sに上がったえ！",
+    "warning_downgraded": "%sは%sに下がったえ",
+    "warning_cancelled": "%sは解除されたえ",
+    "advice": {
+      "umbrella": "傘を持っていっておくれやす。",
+      "folding_umbrella": "折りたたみ傘を持っといやす。",
+      "coat": "コートを着ていっておくれやす。",
+      "layer": "脱ぎ着しやすい服にしておくれやす。",
+      "short_sleeves": "半袖でよろしおす。",
+      "laundry": "洗濯物はお外に干せますえ。",
+      "indoor_laundry": "洗濯物はお部屋に干しとくれやす。"
+    }
+  },
+  "rules": [
+    {"from": "　", "to": " ",
+     "examples": [{"in": "晴れ　所により雨", "out": "☀ ところによって☔"}]},
+    {"from": "後", "to": "から",
+     "examples": [{"in": "晴れ後くもり", "out": "☀から☁"}]},
+    {"from": "午後", "to": "午後",
+     "examples": [{"in": "午後から雨", "out": "午後から☔"}]},
+    {"from": "一時", "to": "ちょっとの間",
+     "examples": [{"in": "くもり一時雨", "out": "☁ちょっとの間☔"}]},
+    {"from": "を伴う", "to": "もある",
+     "examples": [{"in": "雨で雷を伴う", "out": "☔で⚡もある"}]},
+    {"from": "時々", "to": "ときどき",
+     "examples": [{"in": "晴れ時々くもり", "out": "☀ときどき☁"}]},
+    {"from": "を伴い", "to": "もあって",
+     "examples": [{"in": "雷を伴い", "out": "⚡もあって"}]},
+    {"from": "非常に", "to": "えらい",
+     "examples": [{"in": "非常に激しく降る", "out": "えらいきつう降る"}]},
+    {"from": "激しく", "to": "きつう",
+     "examples": [{"in": "激しく降る", "out": "きつう降る"}]},
+    {"from": "山地", "to": "山の方",
+     "examples": [{"in": "山地では雪", "out": "山の方では⛄"}]},
+    {"from": "未明", "to": "夜明け前",
+     "examples": [{"in": "未明から雨", "out": "夜明け前から☔"}]},
+    {"from": "所により", "to": "ところによって",
+     "examples": [{"in": "所により雷", "out": "ところによって⚡"}]},
+    {"from": "海上", "to": "海の方",
+     "examples": [{"in": "海上では", "out": "海の方では"}]},
+    {"from": "夜遅く", "to": "夜遅う",
+     "examples": [{"in": "夜遅くから", "out": "夜遅うから"}]},
+    {"from": "夜のはじめ頃", "to": "日ぃの暮れる頃",
+     "examples": [{"in": "夜のはじめ頃から雨", "out": "日ぃの暮れる頃から☔"}]},
+    {"from": "晴れ", "to": "☀",
+     "examples": [{"in": "晴れ", "out": "☀"}]},
+    {"from": "雨", "to": "☔",
+     "examples": [{"in": "雨", "out": "☔"}]},
+    {"from": "雪", "to": "⛄",
+     "examples": [{"in": "雪", "out": "⛄"}]},
+    {"from": "くもり", "to": "☁",
+     "examples": [{"in": "くもり", "out": "☁"}]},
+    {"from": "雷", "to": "⚡",
+     "examples": [{"in": "雷", "out": "⚡"}]}
+  ]
+}
+`
+
+const hakataJSON = `{
+  "name": "hakata",
+  "phrases": {
+    "intro": "%sの%sの天気は基本%s",
+    "end": "たい。",
+    "topic": "は",
+    "sub_area": "%sらしかよ",
+    "lowest": "いっちゃん低か気温は %s",
+    "highest": "いっちゃん高か気温は %s",
+    "highest_end": "たい",
+    "higher": "%sより%d度高かよ",
+    "lower": "%sより%d度低かよ",
+    "same": "%sと同じくらいたい",
+    "unknown": "わからん",
+    "below_zero": "氷点下%d度",
+    "pop": "%sから雨の確率%d%%たい",
+    "wind": "風は%sたい",
+    "wave": "波は%sたい",
+    "tag": "#bam_weather",
+    "cancelled": "%sの天気予報は気象台が取り消したと",
+    "weekly_intro": "%sの週間天気たい",
+    "warning_intro": "%sの警報・注意報たい",
+    "warning_issued": "%sの出たばい！",
+    "warning_upgraded": "%sが%sに上がったばい！",
+    "warning_downgraded": "%sは%sに下がったばい",
+    "warning_cancelled": "%sは解除されたばい",
+    "advice": {
+      "umbrella": "傘ば持っていかんね。",
+      "folding_umbrella": "折りたたみ傘ば持っとかんね。",
+      "coat": "コートば着ていかんね。",
+      "layer": "脱いだり着たりできる服にしとかんね。",
+      "short_sleeves": "半袖でよかよ。",
+      "laundry": "洗濯物は外に干せるばい。",
+      "indoor_laundry": "洗濯物は部屋干しにしとかんね。"
+    }
+  },
+  "rules": [
+    {"from": "　", "to": " ",
+     "examples": [{"in": "晴れ　所により雨", "out": "☀ ところによっては☔"}]},
+    {"from": "後", "to": "から",
+     "examples": [{"in": "晴れ後くもり", "out": "☀から☁"}]},
+    {"from": "午後", "to": "午後",
+     "examples": [{"in": "午後から雨", "out": "午後から☔"}]},
+    {"from": "一時", "to": "ちょっとの間",
+     "examples": [{"in": "くもり一時雨", "out": "☁ちょっとの間☔"}]},
+    {"from": "を伴う", "to": "もある",
+     "examples": [{"in": "雨で雷を伴う", "out": "☔で⚡もある"}]},
+    {"from": "時々", "to": "たまに",
+     "examples": [{"in": "晴れ時々くもり", "out": "☀たまに☁"}]},
+    {"from": "を伴い", "to": "もあって",
+     "examples": [{"in": "雷を伴い", "out": "⚡もあって"}]},
+    {"from": "非常に", "to": "ばり",
+     "examples": [{"in": "非常に激しく降る", "out": "ばりえずう降る"}]},
+    {"from": "激しく", "to": "えずう",
+     "examples": [{"in": "激しく降る", "out": "えずう降る"}]},
+    {"from": "未明", "to": "夜明け前",
+     "examples": [{"in": "未明から雨", "out": "夜明け前から☔"}]},
+    {"from": "所により", "to": "ところによっては",
+     "examples": [{"in": "所により雷", "out": "ところによっては⚡"}]},
+    {"from": "晴れ", "to": "☀",
+     "examples": [{"in": "晴れ", "out": "☀"}]},
+    {"from": "雨", "to": "☔",
+     "examples": [{"in": "雨", "out": "☔"}]},
+    {"from": "雪", "to": "⛄",
+     "examples": [{"in": "雪", "out": "⛄"}]},
+    {"from": "くもり", "to": "☁",
+     "examples": [{"in": "くもり", "out": "☁"}]},
+    {"from": "雷", "to": "⚡",
+     "examples": [{"in": "雷", "out": "⚡"}]}
+  ]
+}
+`
+
+const keigoJSON = `{
+  "name": "keigo",
+  "phrases": {
+    "intro": "%sの%sの天気は%s",
+    "end": "でしょう。",
+    "topic": "は",
+    "sub_area": "なお、%sでしょう",
+    "lowest": "最低気温は %s",
+    "highest": "最高気温は %s",
+    "highest_end": "の予想です",
+    "higher": "%sより%d度高くなる見込みです",
+    "lower": "%sより%d度低くなる見込みです",
+    "same": "%sと同じくらいの見込みです",
+    "unknown": "発表されていません",
+    "below_zero": "氷点下%d度",
+    "pop": "%sから雨の確率%d%%です",
+    "wind": "風は%sでしょう",
+    "wave": "波は%sでしょう",
+    "tag": "",
+    "cancelled": "%sの天気予報は気象台により取り消されました",
+    "weekly_intro": "%sの週間天気予報をお知らせします",
+    "warning_intro": "%sの警報・注意報をお知らせします",
+    "warning_issued": "%sが発表されました",
+    "warning_upgraded": "%sが%sに引き上げられました",
+    "warning_downgraded": "%sは%sに引き下げられました",
+    "warning_cancelled": "%sは解除されました",
+    "advice": {
+      "umbrella": "傘をお持ちください。",
+      "folding_umbrella": "折りたたみ傘があると安心です。",
+      "coat": "コートをお召しください。",
+      "layer": "脱ぎ着しやすい服装がおすすめです。",
+      "short_sleeves": "半袖で過ごせる暑さです。",
+      "laundry": "洗濯物は外に干せそうです。",
+      "indoor_laundry": "洗濯物は部屋干しがおすすめです。"
+    }
+  },
+  "rules": [
+    {"from": "　", "to": " ",
+     "examples": [{"in": "晴れ　所により雨", "out": "晴れ 所により雨"}]},
+    {"from": "後", "to": "のち",
+     "examples": [{"in": "晴れ後くもり", "out": "晴れのちくもり"}]},
+    {"from": "午後", "to": "午後",
+     "examples": [{"in": "午後から雨", "out": "午後から雨"}]}
+  ]
+}
+`
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DirSource reads reports saved as XML files in a local directory.
+// It is meant to run the pipeline offline against fixtures.
+type DirSource struct {
+	Dir    string
+	Office string
+}
+
+func (s *DirSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(s.Dir, "*.xml"))
+	if err != nil {
+		return nil, fmt.Errorf("DirSource:glob error:%v", err)
+	}
+
+	var links []string
+	for _, file := range files {
+		var r Report
+		if err := s.FetchReport(ctx, file, &r); err != nil {
+			log.Println(err)
+			continue
+		}
+		if r.Control.Title != title || r.Control.PublishingOffice != s.Office {
+			continue
+		}
+		tt, err := time.Parse(time.RFC3339, r.Head.ReportDateTime)
+		if err != nil {
+			return nil, fmt.Errorf("DirSource:parse error:%v", err)
+		}
+		if tt.Before(eday) && tt.After(sday) {
+			links = append(links, file)
+		}
+	}
+	if len(links) == 0 {
+		return nil, errors.Wrap(errLinkNotFound, "DirSource")
+	}
+	return links, nil
+}
+
+func (s *DirSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	f, err := os.Open(link)
+	if err != nil {
+		return fmt.Errorf("DirSource:open error:%v", err)
+	}
+	defer f.Close()
+
+	dec := xml.NewDecoder(f)
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("DirSource:decode error:%v", err)
+	}
+	return nil
+}
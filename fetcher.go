@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFetchRetries   = 3
+	defaultFetchTimeout   = 10 * time.Second
+	defaultFetchBaseDelay = 500 * time.Millisecond
+	defaultFetchMaxDelay  = 5 * time.Second
+	defaultFetchMaxBytes  = 10 << 20
+)
+
+// Fetcher fetches JMA XML.
+// Requests are bounded by the context, retried with jittered backoff
+// on network errors and 5xx responses, and their bodies are limited to
+// MaxBytes. Retries is the number of retries after the first attempt,
+// 0 is none and a negative value uses the default. The other zero fields
+// use the defaults.
+// When Archive is set, every fetched feed and report is saved to it.
+// Reports are kept by link, so a report is fetched and archived once
+// however many times it is read.
+type Fetcher struct {
+	Client    *http.Client
+	Retries   int
+	Timeout   time.Duration
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	MaxBytes  int64
+	Archive   Store
+
+	mu      sync.Mutex
+	reports map[string][]byte
+}
+
+// statusError is returned when the server responds with non-200 status.
+type statusError struct {
+	URL    string
+	Status int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status %d for %s", e.Status, e.URL)
+}
+
+// sizeError is returned when the response exceeds MaxBytes.
+type sizeError struct {
+	URL      string
+	MaxBytes int64
+}
+
+func (e *sizeError) Error() string {
+	return fmt.Sprintf("response of %s exceeds %d bytes", e.URL, e.MaxBytes)
+}
+
+// retryable reports whether the request may succeed next time.
+// Network errors are retried, so are 5xx and 429 responses.
+func retryable(err error) bool {
+	switch e := err.(type) {
+	case *statusError:
+		return e.Status >= 500 || e.Status == http.StatusTooManyRequests
+	case *sizeError:
+		return false
+	}
+	return true
+}
+
+func (f *Fetcher) fetch(ctx context.Context, link string) ([]byte, error) {
+	if f == nil {
+		f = &Fetcher{Retries: -1}
+	}
+	retries := f.Retries
+	if retries < 0 {
+		retries = defaultFetchRetries
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var data []byte
+		data, err = f.fetchOnce(ctx, link)
+		if err == nil {
+			return data, nil
+		}
+		if !retryable(err) || ctx.Err() != nil || attempt >= retries {
+			break
+		}
+
+		delay := f.backoff(attempt)
+		log.Printf("fetch failed (%v), retry in %v\n", err, delay)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("fetch error:%v", ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+	return nil, fmt.Errorf("fetch error:%v", err)
+}
+
+// backoff returns a random delay up to BaseDelay * 2^attempt, capped by MaxDelay.
+func (f *Fetcher) backoff(attempt int) time.Duration {
+	base := f.BaseDelay
+	if base == 0 {
+		base = defaultFetchBaseDelay
+	}
+	max := f.MaxDelay
+	if max == 0 {
+		max = defaultFetchMaxDelay
+	}
+	d := base << uint(attempt)
+	if d > max || d <= 0 {
+		d = max
+	}
+	return time.Duration(rand.Int63n(int64(d))) + 1
+}
+
+func (f *Fetcher) fetchOnce(ctx context.Context, link string) ([]byte, error) {
+	log.Println("Fetch URL:", link)
+
+	timeout := f.Timeout
+	if timeout == 0 {
+		timeout = defaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+		return nil, &statusError{URL: link, Status: resp.StatusCode}
+	}
+
+	maxBytes := f.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = defaultFetchMaxBytes
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, &sizeError{URL: link, MaxBytes: maxBytes}
+	}
+	return data, nil
+}
+
+// FetchFeed fetches the feed at link and decodes it into v.
+func (f *Fetcher) FetchFeed(ctx context.Context, link string, v interface{}) error {
+	data, err := f.fetch(ctx, link)
+	if err != nil {
+		return fmt.Errorf("FetchFeed:%v", err)
+	}
+
+	if f != nil && f.Archive != nil {
+		key := feedArchiveKey(time.Now(), link)
+		if err := f.Archive.Put(key, "application/xml", data); err != nil {
+			log.Println("failed to archive feed:", err)
+		}
+	}
+
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return fmt.Errorf("FetchFeed:decode error:%v", err)
+	}
+	return nil
+}
+
+// FetchJSON fetches the JSON at link and decodes it into v.
+func (f *Fetcher) FetchJSON(ctx context.Context, link string, v interface{}) error {
+	data, err := f.fetch(ctx, link)
+	if err != nil {
+		return fmt.Errorf("FetchJSON:%v", err)
+	}
+
+	if f != nil && f.Archive != nil {
+		key := feedArchiveKey(time.Now(), link)
+		if err := f.Archive.Put(key, "application/json", data); err != nil {
+			log.Println("failed to archive json:", err)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("FetchJSON:decode error:%v", err)
+	}
+	return nil
+}
+
+// FetchReport fetches the report at link and decodes it into v.
+func (f *Fetcher) FetchReport(ctx context.Context, link string, v interface{}) error {
+	data, err := f.fetchReport(ctx, link)
+	if err != nil {
+		return fmt.Errorf("FetchReport:%v", err)
+	}
+
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(v); err != nil {
+		return fmt.Errorf("FetchReport:decode error:%v", err)
+	}
+	return nil
+}
+
+// fetchReport returns the report at link, fetching and archiving it
+// only when it is not kept yet.
+func (f *Fetcher) fetchReport(ctx context.Context, link string) ([]byte, error) {
+	if f == nil {
+		return f.fetch(ctx, link)
+	}
+	f.mu.Lock()
+	data, ok := f.reports[link]
+	f.mu.Unlock()
+	if ok {
+		return data, nil
+	}
+
+	data, err := f.fetch(ctx, link)
+	if err != nil {
+		return nil, err
+	}
+	if f.Archive != nil {
+		if err := archiveReport(f.Archive, link, data); err != nil {
+			log.Println("failed to archive report:", err)
+		}
+	}
+
+	f.mu.Lock()
+	if f.reports == nil {
+		f.reports = map[string][]byte{}
+	}
+	f.reports[link] = data
+	f.mu.Unlock()
+	return data, nil
+}
+
+func feedArchiveKey(tt time.Time, link string) string {
+	return fmt.Sprintf("archive/feed/%s_%s", tt.Format("20060102/150405"), path.Base(link))
+}
+
+// reportArchiveKey returns the key of a report, which is made of
+// its report time and EventID. Reports are listed by date under
+// archive/report/.
+func reportArchiveKey(r *Report, link string) (string, error) {
+	tt, err := time.Parse(time.RFC3339, r.Head.ReportDateTime)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("archive/report/%s_%s_%s", tt.Format("20060102/150405"), r.Head.EventID, path.Base(link)), nil
+}
+
+func archiveReport(archive Store, link string, data []byte) error {
+	var r Report
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&r); err != nil {
+		return err
+	}
+	key, err := reportArchiveKey(&r, link)
+	if err != nil {
+		return err
+	}
+	return archive.Put(key, "application/xml", data)
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testReport = `<?xml version="1.0" encoding="UTF-8"?>
+<Report xmlns="http://xml.kishou.go.jp/jmaxml1/">
+<Control><Title>府県天気予報（Ｒ１）</Title><DateTime>2020-05-10T07:59:00Z</DateTime><Status>通常</Status></Control>
+<Head xmlns="http://xml.kishou.go.jp/jmaxml1/informationBasis1/"><ReportDateTime>2020-05-10T17:00:00+09:00</ReportDateTime><EventID>270000</EventID><InfoType>発表</InfoType><Serial></Serial></Head>
+</Report>`
+
+func TestFetchReportOnce(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, testReport)
+	}))
+	defer ts.Close()
+
+	f := &Fetcher{}
+	ctx := context.Background()
+	var h reportHeader
+	if err := f.FetchReport(ctx, ts.URL, &h); err != nil {
+		t.Fatal(err)
+	}
+	var r Report
+	if err := f.FetchReport(ctx, ts.URL, &r); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("fetched %d times, want 1", requests)
+	}
+	if r.Head.EventID != "270000" || h.Head.EventID != r.Head.EventID {
+		t.Errorf("EventID = %q, %q", h.Head.EventID, r.Head.EventID)
+	}
+}
+
+func TestFetchRetries(t *testing.T) {
+	tests := []struct {
+		retries  int
+		requests int
+	}{
+		{0, 1},
+		{2, 3},
+		{-1, defaultFetchRetries + 1},
+	}
+	for _, tt := range tests {
+		requests := 0
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		f := &Fetcher{Retries: tt.retries, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+		if _, err := f.fetch(context.Background(), ts.URL); err == nil {
+			t.Errorf("Retries %d: fetch succeeded", tt.retries)
+		}
+		ts.Close()
+		if requests != tt.requests {
+			t.Errorf("Retries %d: requested %d times, want %d", tt.retries, requests, tt.requests)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bamchoh/bam-weather/advice"
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/bamchoh/bam-weather/weathercode"
+)
+
+// Forecast is the daily forecast before it is put into words by a persona
+// or a language. Date is the forecasted day, which is tomorrow when Tomorrow
+// is set, otherwise today.
+// Wind and Wave are empty when they are not in the text.
+// Advice is empty unless it is switched on by the options.
+// URL is the link to index.html on the last line.
+type Forecast struct {
+	Region      Region
+	Date        time.Time
+	Tomorrow    bool
+	Weather     WeatherForecastPart
+	WeatherCode string
+	Temps       DayTemps
+	Unit        temp.Unit
+	POP         [4]int
+	Wind        string
+	Wave        string
+	Advice      []advice.Item
+	URL         string
+}
+
+func newForecast(region Region, day *DayInfo, temps DayTemps, date time.Time, tomorrow bool, opts Options) Forecast {
+	f := Forecast{
+		Region:      region,
+		Date:        date,
+		Tomorrow:    tomorrow,
+		Weather:     day.Weather,
+		WeatherCode: day.WeatherCode,
+		Temps:       temps,
+		Unit:        opts.Unit(),
+		POP:         day.POP,
+	}
+	if opts.Wind {
+		f.Wind = day.Wind
+	}
+	if opts.Wave {
+		f.Wave = day.Wave
+	}
+	if opts.Advice {
+		f.Advice = dayAdvice(day, temps, opts)
+	}
+	return f
+}
+
+// dayAdvice returns the advice of the day by the thresholds of opts.
+// The weather of the weather code is used when it is known.
+func dayAdvice(day *DayInfo, temps DayTemps, opts Options) []advice.Item {
+	weather := day.Weather.Base.Weather.Text
+	for _, ws := range [][]WeatherInfo{day.Weather.Temporary, day.Weather.Becoming} {
+		for _, w := range ws {
+			weather += w.TimeModifier + w.Weather.Text
+		}
+	}
+	if t, ok := weathercode.Lookup(day.WeatherCode); ok {
+		weather = t.Text
+	}
+	return opts.AdviceThresholds.Advise(advice.Day{
+		Weather: weather,
+		POP:     day.POP,
+		Low:     temps.Low,
+		High:    temps.High,
+	})
+}
+
+// adviceText returns the sentences of the advice in the voice of p.
+func adviceText(items []advice.Item, p *dialect.Persona) string {
+	text := ""
+	for _, item := range items {
+		text += p.Phrases.Advice[item]
+	}
+	return text
+}
+
+// when returns the day in the text, e.g. 今日(9月10日).
+func (f Forecast) when() string {
+	name := "今日"
+	if f.Tomorrow {
+		name = "明日"
+	}
+	return fmt.Sprintf("%s(%s)", name, f.Date.Format("1月2日"))
+}
+
+// compareText returns the difference from the day before,
+// e.g. 昨日より3度高いで. It is empty when either is missing.
+func compareText(t, prev temp.Temp, prevName string, u temp.Unit, p *dialect.Persona) string {
+	d, ok := t.Delta(prev, u)
+	switch {
+	case !ok:
+		return ""
+	case d > 0:
+		return fmt.Sprintf(p.Phrases.Higher, prevName, d)
+	case d < 0:
+		return fmt.Sprintf(p.Phrases.Lower, prevName, -d)
+	}
+	return fmt.Sprintf(p.Phrases.Same, prevName)
+}
+
+// popThreshold is the probability of precipitation from which rain is
+// mentioned in the forecast.
+const popThreshold = 30
+
+// popSpan returns the first period whose probability reaches popThreshold
+// and the highest probability of the day. start is -1 when no period
+// reaches it.
+func popSpan(pop [4]int) (start, peak int) {
+	start, peak = -1, -1
+	for i, v := range pop {
+		if start < 0 && v >= popThreshold {
+			start = i
+		}
+		if v > peak {
+			peak = v
+		}
+	}
+	return start, peak
+}
+
+// generatePOP returns the rain of the day in the voice of p,
+// e.g. 昼から雨の確率70%やで with the highest probability of the day.
+// It is empty when no period reaches popThreshold.
+func generatePOP(pop [4]int, p *dialect.Persona) string {
+	names := []string{"夜中", "朝", "昼", "夜"}
+	start, peak := popSpan(pop)
+	if start < 0 {
+		return ""
+	}
+	return fmt.Sprintf(p.Phrases.POP, names[start], peak)
+}
+
+// tempText returns t in words, e.g. 25度 or 氷点下3度.
+func tempText(t temp.Temp, u temp.Unit, p *dialect.Persona) string {
+	switch {
+	case !t.Valid():
+		return p.Phrases.Unknown
+	case u == temp.Fahrenheit:
+		return t.Format(u) + u.Symbol()
+	case t.Round(u) < 0:
+		return fmt.Sprintf(p.Phrases.BelowZero, -t.Round(u))
+	}
+	return t.Format(u) + "度"
+}
+
+// weatherSentence returns the weather of f in the voice of p,
+// e.g. ☀や。夕方からは☁や。
+func weatherSentence(f Forecast, p *dialect.Persona) string {
+	var ws []WeatherInfo
+
+	wf := f.Weather
+	ws = append(ws, wf.Base)
+	ws = append(ws, wf.Temporary...)
+	ws = append(ws, wf.Becoming...)
+
+	searchText := []string{"時々", "後"}
+	report := ""
+	for _, w := range ws {
+		if w.TimeModifier != "" {
+			if !w.Exists(searchText) {
+				report += p.Phrases.End
+			}
+			report += p.Apply(w.TimeModifier)
+			if !w.Exists(searchText) {
+				report += p.Phrases.Topic
+			}
+		}
+		report += p.Apply(w.Weather.Text)
+	}
+	report += p.Phrases.End
+
+	if wf.SubArea.Sentence != "" {
+		report += fmt.Sprintf(p.Phrases.SubArea, p.Apply(wf.SubArea.Sentence))
+	}
+	return report
+}
+
+// generateForecast returns the text of f in the voice of p
+// with the default template. When it fails to execute, the error is logged
+// and the text is made by the built-in standard persona, or is the region,
+// the day and the link as the last resort.
+func generateForecast(f Forecast, p *dialect.Persona) string {
+	text, err := executeForecastTemplate(defaultForecastTemplate, f, p)
+	if err == nil {
+		return text
+	}
+	log.Println(err)
+
+	text, err = executeForecastTemplate(defaultForecastTemplate, f, dialect.Personas()[dialect.Standard])
+	if err == nil {
+		return text
+	}
+	log.Println(err)
+	return strings.TrimSpace(fmt.Sprintf("%sの%sの天気\n%s", f.Region.Name, f.when(), f.URL))
+}
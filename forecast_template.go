@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/bamchoh/bam-weather/advice"
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/pkg/errors"
+)
+
+// forecastTemplateText is the default layout of the daily forecast.
+const forecastTemplateText = `{{ printf .Phrases.Intro .Region.Name .When weather }}
+{{ printf .Phrases.Lowest (temp .Temps.Low) }}{{ with compare .Temps.Low .Temps.PrevLow }}、{{ . }}{{ end }}
+{{ printf .Phrases.Highest (temp .Temps.High) }}{{ with compare .Temps.High .Temps.PrevHigh }}、{{ . }}{{ else }}{{ .Phrases.HighestEnd }}{{ end }}
+{{- with pop .POP }}
+{{ . }}{{ end }}
+{{- with advice .Advice }}
+{{ . }}{{ end }}
+{{- with .Wind }}
+{{ printf $.Phrases.Wind (dialect .) }}{{ end }}
+{{- with .Wave }}
+{{ printf $.Phrases.Wave (dialect .) }}{{ end }}
+{{- with .Phrases.Tag }}
+{{ . }}{{ end }}
+{{- with .URL }}
+{{ . }}{{ end }}`
+
+var defaultForecastTemplate = template.Must(parseForecastTemplate("default", forecastTemplateText))
+
+// forecastData is the data of forecast templates.
+// When is the day in Japanese, e.g. 今日(9月10日).
+type forecastData struct {
+	Forecast
+	When    string
+	Phrases dialect.Phrases
+}
+
+var emojiReplacer = strings.NewReplacer(
+	"晴れ", "☀",
+	"くもり", "☁",
+	"雨", "☔",
+	"雪", "⛄",
+	"雷", "⚡",
+)
+
+// forecastFuncs returns the helper functions of forecast templates.
+// dialect converts a sentence of JMA reports by the persona and emoji
+// replaces weathers with emoji. weather, temp, compare and pop are the parts
+// of the default text, compare and pop are empty when they are unknown.
+// advice is the sentences of the advice items.
+func forecastFuncs(f Forecast, p *dialect.Persona) template.FuncMap {
+	return template.FuncMap{
+		"dialect": p.Apply,
+		"emoji":   emojiReplacer.Replace,
+		"weather": func() string {
+			return weatherSentence(f, p)
+		},
+		"temp": func(t temp.Temp) string {
+			return tempText(t, f.Unit, p)
+		},
+		"compare": func(t, prev temp.Temp) string {
+			return compareText(t, prev, f.Temps.PrevName, f.Unit, p)
+		},
+		"pop": func(pop [4]int) string {
+			return generatePOP(pop, p)
+		},
+		"advice": func(items []advice.Item) string {
+			return adviceText(items, p)
+		},
+	}
+}
+
+// parseForecastTemplate parses a forecast template.
+func parseForecastTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(forecastFuncs(Forecast{}, dialect.Personas()[dialect.Osaka])).Parse(text)
+}
+
+// executeForecastTemplate returns the text of f made by t in the voice of p.
+func executeForecastTemplate(t *template.Template, f Forecast, p *dialect.Persona) (string, error) {
+	t, err := t.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	err = t.Funcs(forecastFuncs(f, p)).Execute(&buffer, forecastData{
+		Forecast: f,
+		When:     f.when(),
+		Phrases:  p.Phrases,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to execute template %s", t.Name())
+	}
+	return buffer.String(), nil
+}
+
+// loadForecastTemplates reads the templates of the channels from the store.
+func loadForecastTemplates(cfg *Config, store Store) error {
+	for i, c := range cfg.Channels {
+		if c.Template == "" {
+			continue
+		}
+		data, err := store.Get(c.Template)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get template %s", c.Template)
+		}
+		t, err := parseForecastTemplate(c.Template, string(data))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse template %s", c.Template)
+		}
+		cfg.Channels[i].template = t
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func TestGeneratePOP(t *testing.T) {
+	p := dialect.Personas()[dialect.Standard]
+	tests := []struct {
+		pop  [4]int
+		want string
+	}{
+		{[4]int{0, 0, 0, 0}, ""},
+		{[4]int{-1, -1, -1, -1}, ""},
+		{[4]int{-1, 0, 10, 20}, ""},
+		{[4]int{0, 30, 50, 20}, "朝から雨の確率50%"},
+		{[4]int{10, 20, 40, 80}, "昼から雨の確率80%"},
+		{[4]int{90, 30, 0, 0}, "夜中から雨の確率90%"},
+	}
+	for _, tt := range tests {
+		if got := generatePOP(tt.pop, p); got != tt.want {
+			t.Errorf("generatePOP(%v) = %q, want %q", tt.pop, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateForecastFallback(t *testing.T) {
+	f := Forecast{
+		Region:  regions["osaka"],
+		Date:    time.Date(2020, 9, 10, 0, 0, 0, 0, time.Local),
+		Weather: WeatherForecastPart{Base: WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}}},
+		Temps:   DayTemps{Low: temp.New(22, temp.Celsius), High: temp.New(31, temp.Celsius)},
+		Unit:    temp.Celsius,
+		URL:     "https://example.com/index.html",
+	}
+	osaka := dialect.Personas()[dialect.Osaka]
+	want := generateForecast(f, osaka)
+
+	// a user template which fails is replaced by the default one
+	broken := template.Must(parseForecastTemplate("broken", "{{ .Nope }}"))
+	c := Channel{Type: "test", Persona: dialect.Osaka, template: broken}
+	if got := c.forecastText(f); got != want {
+		t.Errorf("forecastText() with a broken template = %q, want %q", got, want)
+	}
+
+	// the default template itself is not expected to fail, but it does not panic
+	defer func(t *template.Template) { defaultForecastTemplate = t }(defaultForecastTemplate)
+	defaultForecastTemplate = broken
+	got := generateForecast(f, osaka)
+	if !strings.HasPrefix(got, f.Region.Name+"の今日(9月10日)の天気") || !strings.HasSuffix(got, f.URL) {
+		t.Errorf("generateForecast() with a broken default template = %q", got)
+	}
+}
@@ -5,40 +5,108 @@ import (
 	"html/template"
 	"io"
 	"time"
+
+	"github.com/bamchoh/bam-weather/i18n"
 )
 
-func dayString(day time.Time) string {
+const baseURL = "https://s3-ap-northeast-1.amazonaws.com/bam-weather/"
+
+// Page is a page for the image. Lang is the language of the page, ja when empty.
+// Alt is the alt text of the image, the title when empty.
+type Page struct {
+	Title  string
+	Lang   string
+	Path   string
+	Image  string
+	Alt    string
+	Serial int64
+}
+
+// dayString returns day in the language, Japanese when lang is not supported.
+func dayString(lang string, day time.Time) string {
+	if l, ok := i18n.Lookup(lang); ok {
+		return l.Day(day)
+	}
 	wdays := []string{"日", "月", "火", "水", "木", "金", "土"}
 	return fmt.Sprintf("%d月%d日(%s)", day.Month(), day.Day(), wdays[day.Weekday()])
 }
 
-func Generate(f io.Writer, day time.Time, serial int64) error {
+func Generate(f io.Writer, lang string, name string, day time.Time, alt string, serial int64) error {
+	format := "%sの天気 %s"
+	if l, ok := i18n.Lookup(lang); ok {
+		format = l.Phrases.PageDaily
+	}
+	return GeneratePage(f, Page{
+		Title:  fmt.Sprintf(format, name, dayString(lang, day)),
+		Lang:   lang,
+		Path:   "index.html",
+		Image:  "weather.png",
+		Alt:    alt,
+		Serial: serial,
+	})
+}
+
+func GenerateWeekly(f io.Writer, lang string, name string, day time.Time, serial int64) error {
+	format := "%sの週間天気 %s〜"
+	if l, ok := i18n.Lookup(lang); ok {
+		format = l.Phrases.PageWeekly
+	}
+	return GeneratePage(f, Page{
+		Title:  fmt.Sprintf(format, name, dayString(lang, day)),
+		Lang:   lang,
+		Path:   "weekly.html",
+		Image:  "weekly.png",
+		Serial: serial,
+	})
+}
+
+func GenerateWarning(f io.Writer, lang string, name string, day time.Time, serial int64) error {
+	format := "%sの警報・注意報 %s"
+	if l, ok := i18n.Lookup(lang); ok {
+		format = l.Phrases.PageWarning
+	}
+	return GeneratePage(f, Page{
+		Title:  fmt.Sprintf(format, name, dayString(lang, day)),
+		Lang:   lang,
+		Path:   "warning.html",
+		Image:  "warning.png",
+		Serial: serial,
+	})
+}
+
+func GeneratePage(f io.Writer, p Page) error {
 	const html = `<!DOCTYPE html>
-<html>
+<html lang="{{ if .Lang }}{{ .Lang }}{{ else }}ja{{ end }}">
   <head>
     <meta charset="utf-8" />
-    <meta property="og:title" content="大阪の天気 {{ .Today }}" />
+    <meta property="og:title" content="{{ .Title }}" />
     <meta property="og:type" content="article" />
-    <meta property="og:url" content="https://s3-ap-northeast-1.amazonaws.com/bam-weather/index.html?{{ .Serial }}" />
-    <meta property="og:image" content="https://s3-ap-northeast-1.amazonaws.com/bam-weather/weather.png?{{ .Serial }}" />
+    <meta property="og:url" content="{{ .BaseURL }}{{ .Path }}?{{ .Serial }}" />
+    <meta property="og:image" content="{{ .BaseURL }}{{ .Image }}?{{ .Serial }}" />
+    <meta property="og:image:alt" content="{{ .Alt }}" />
     <meta name="twitter:card" content="summary_large_image">
+    <meta name="twitter:image:alt" content="{{ .Alt }}">
     <meta name="twitter:site" content="@bamchoh">
-    <title>大阪の天気 {{ .Today }}</title>
+    <title>{{ .Title }}</title>
   </head>
   <body>
-    <img src="https://s3-ap-northeast-1.amazonaws.com/bam-weather/weather.png" />
+    <img src="{{ .BaseURL }}{{ .Image }}" alt="{{ .Alt }}" />
   </body>
 </html>
 `
 
 	t := template.Must(template.New("html").Parse(html))
 
+	if p.Alt == "" {
+		p.Alt = p.Title
+	}
+
 	err := t.Execute(f, struct {
-		Today  string
-		Serial int64
+		Page
+		BaseURL string
 	}{
-		Today:  dayString(day),
-		Serial: serial,
+		Page:    p,
+		BaseURL: baseURL,
 	})
 	return err
 }
@@ -0,0 +1,48 @@
+package genpng
+
+import (
+	"strings"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func altTemp(t temp.Temp, u temp.Unit) string {
+	if u == temp.Fahrenheit {
+		return t.Format(u) + u.Symbol()
+	}
+	return t.Format(u) + "度"
+}
+
+// AltText returns the description of the weather image,
+// e.g. 晴れのちくもり、最高18度、最低9度. It is Alt when it is set.
+// Missing temperatures are left out.
+func (info WeatherInfo) AltText() string {
+	if info.Alt != "" {
+		return info.Alt
+	}
+	parts := []string{info.First + info.Second + info.Third + info.Fourth + info.Fifth}
+	if info.High.Valid() {
+		parts = append(parts, "最高"+altTemp(info.High, info.Unit))
+	}
+	if info.Low.Valid() {
+		parts = append(parts, "最低"+altTemp(info.Low, info.Unit))
+	}
+	return strings.Join(parts, "、")
+}
+
+// AltText returns the description of the chart,
+// e.g. 06:00 晴れ 25度、09:00 くもり 27度.
+func (chart HourlyChart) AltText() string {
+	var slots []string
+	for _, s := range chart.Slots {
+		parts := []string{s.Label}
+		if s.Weather != "" {
+			parts = append(parts, s.Weather)
+		}
+		if s.Temp.Valid() {
+			parts = append(parts, altTemp(s.Temp, chart.Unit))
+		}
+		slots = append(slots, strings.Join(parts, " "))
+	}
+	return strings.Join(slots, "、")
+}
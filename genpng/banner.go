@@ -0,0 +1,68 @@
+package genpng
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+)
+
+// WarningBanner is a list of warning changes.
+type WarningBanner struct {
+	Title string
+	Items []WarningBannerItem
+}
+
+// WarningBannerItem is a row of WarningBanner.
+// Level is 0 for cancellations, 1 for 注意報, 2 for 警報 and 3 for 特別警報.
+type WarningBannerItem struct {
+	Text  string
+	Level int
+}
+
+var warningColors = []color.RGBA{
+	{120, 120, 120, 255},
+	{240, 200, 0, 255},
+	{220, 30, 30, 255},
+	{110, 0, 150, 255},
+}
+
+// GenerateBanner writes the image of the warning changes in PNG.
+func GenerateBanner(banner WarningBanner, buffer io.Writer) error {
+	return defaultRenderer.GenerateBanner(banner, buffer)
+}
+
+// GenerateBanner writes the image of the warning changes in PNG.
+func (r *Renderer) GenerateBanner(banner WarningBanner, buffer io.Writer) error {
+	rowH := 44
+	w := 500
+	h := 56 + rowH*len(banner.Items)
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	bg := image.NewUniform(color.RGBA{40, 40, 40, 255})
+	draw.Draw(m, m.Bounds(), bg, image.ZP, draw.Src)
+
+	white := color.RGBA{255, 255, 255, 255}
+	_, err := r.drawString(banner.Title, 28, white, m, 16, 10)
+	if err != nil {
+		return err
+	}
+
+	for i, item := range banner.Items {
+		level := item.Level
+		if level < 0 || level >= len(warningColors) {
+			level = 0
+		}
+		y := 52 + i*rowH
+		row := image.Rect(8, y, w-8, y+rowH-6)
+		draw.Draw(m, row, image.NewUniform(warningColors[level]), image.ZP, draw.Src)
+
+		_, err = r.drawString(item.Text, 24, white, m, 20, y+6)
+		if err != nil {
+			return err
+		}
+	}
+
+	return png.Encode(buffer, m)
+}
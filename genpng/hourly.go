@@ -0,0 +1,159 @@
+package genpng
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+// HourlyChart is a 3-hourly forecast drawn as a line chart of temperature.
+type HourlyChart struct {
+	Slots []HourlySlot
+	Unit  temp.Unit
+	// Look is the background, the sky of the first weather.
+	Look Look
+}
+
+// weather returns the first weather of the slots.
+func (chart HourlyChart) weather() string {
+	for _, s := range chart.Slots {
+		if s.Weather != "" {
+			return s.Weather
+		}
+	}
+	return ""
+}
+
+// HourlySlot is a point of HourlyChart.
+// Weather is empty when no icon is drawn.
+type HourlySlot struct {
+	Label   string
+	Weather string
+	Temp    temp.Temp
+}
+
+// tempRange returns the lowest and highest temperatures of the slots.
+func (chart HourlyChart) tempRange() (low, high int, ok bool) {
+	for _, s := range chart.Slots {
+		if !s.Temp.Valid() {
+			continue
+		}
+		v := s.Temp.Round(chart.Unit)
+		if !ok || v < low {
+			low = v
+		}
+		if !ok || v > high {
+			high = v
+		}
+		ok = true
+	}
+	return
+}
+
+// drawLine draws a line 3 pixels thick from (x0, y0) to (x1, y1).
+func drawLine(m draw.Image, x0, y0, x1, y1 int, c color.Color) {
+	abs := func(v int) int {
+		if v < 0 {
+			return -v
+		}
+		return v
+	}
+	steps := abs(x1 - x0)
+	if d := abs(y1 - y0); d > steps {
+		steps = d
+	}
+	if steps == 0 {
+		steps = 1
+	}
+	src := image.NewUniform(c)
+	for i := 0; i <= steps; i++ {
+		x := x0 + (x1-x0)*i/steps
+		y := y0 + (y1-y0)*i/steps
+		draw.Draw(m, image.Rect(x-1, y-1, x+2, y+2), src, image.ZP, draw.Over)
+	}
+}
+
+func drawDot(m draw.Image, x, y, r int, c color.Color) {
+	for dy := -r; dy <= r; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if dx*dx+dy*dy <= r*r {
+				m.Set(x+dx, y+dy, c)
+			}
+		}
+	}
+}
+
+// GenerateHourly writes the image of the chart in PNG.
+func GenerateHourly(chart HourlyChart, buffer io.Writer) error {
+	return defaultRenderer.GenerateHourly(chart, buffer)
+}
+
+// GenerateHourly writes the image of the chart in PNG.
+func (r *Renderer) GenerateHourly(chart HourlyChart, buffer io.Writer) error {
+	colW := 64
+	margin := 20
+	w := margin*2 + colW*len(chart.Slots)
+	h := 230
+	chartTop := 120
+	chartH := 80
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	chart.Look.paint(m, m.Bounds(), chart.weather())
+
+	white := color.RGBA{255, 255, 255, 255}
+	lineColor := color.RGBA{255, 140, 0, 255}
+	low, high, ok := chart.tempRange()
+	if high == low {
+		high = low + 1
+	}
+
+	var prev *image.Point
+	for i, slot := range chart.Slots {
+		x := margin + i*colW
+		_, err := r.drawString(slot.Label, 16, white, m, x+10, 8)
+		if err != nil {
+			return err
+		}
+
+		if slot.Weather != "" {
+			_, err = r.drawWeather(slot.Weather, 44, m, x+10, 32)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !ok || !slot.Temp.Valid() {
+			prev = nil
+			continue
+		}
+		v := slot.Temp.Round(chart.Unit)
+		pt := image.Pt(x+colW/2, chartTop+chartH-(v-low)*chartH/(high-low))
+		if prev != nil {
+			drawLine(m, prev.X, prev.Y, pt.X, pt.Y, lineColor)
+		}
+		prev = &pt
+	}
+
+	// dots and labels are drawn over the line
+	for i, slot := range chart.Slots {
+		if !ok || !slot.Temp.Valid() {
+			continue
+		}
+		x := margin + i*colW
+		v := slot.Temp.Round(chart.Unit)
+		pt := image.Pt(x+colW/2, chartTop+chartH-(v-low)*chartH/(high-low))
+		drawDot(m, pt.X, pt.Y, 4, white)
+		_, err := r.drawString(fmt.Sprintf("%d°", v), 16, white, m, pt.X-12, pt.Y-26)
+		if err != nil {
+			return err
+		}
+	}
+
+	chart.Look.paintAccent(m)
+	return png.Encode(buffer, m)
+}
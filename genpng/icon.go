@@ -0,0 +1,147 @@
+package genpng
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"log"
+	"strings"
+
+	"golang.org/x/image/math/fixed"
+)
+
+// iconFiles are the weathers drawn by an asset.
+var iconFiles = map[string]string{
+	"晴れ":  "/assets/sun.png",
+	"くもり": "/assets/cloud.png",
+	"雨":   "/assets/rain.png",
+	"雪":   "/assets/snow.png",
+	"雷":   "/assets/thunder.png",
+}
+
+// composites are the weathers drawn by putting assets together.
+var composites = map[string]func(r *Renderer, size uint, m draw.Image, x, y int) (fixed.Point26_6, error){
+	"霧":   (*Renderer).drawFog,
+	"みぞれ": (*Renderer).drawSleet,
+	"雨か雪": (*Renderer).drawRainOrSnow,
+	"暴風":  (*Renderer).drawStorm,
+}
+
+// iconFallbacks choose the icon of a weather without its own icon
+// by the first word in it, e.g. 雪か雨 is drawn as 雨か雪 and 大雨 as 雨.
+var iconFallbacks = []struct {
+	word string
+	icon string
+}{
+	{"暴風", "暴風"},
+	{"雷", "雷"},
+	{"みぞれ", "みぞれ"},
+	{"雨か雪", "雨か雪"},
+	{"雪か雨", "雨か雪"},
+	{"雪", "雪"},
+	{"雨", "雨"},
+	{"霧", "霧"},
+	{"晴", "晴れ"},
+	{"くもり", "くもり"},
+	{"曇", "くもり"},
+}
+
+// iconFor returns the icon of wType. くもり is used when no word matches.
+func iconFor(wType string) string {
+	if _, ok := iconFiles[wType]; ok {
+		return wType
+	}
+	if _, ok := composites[wType]; ok {
+		return wType
+	}
+	icon := "くもり"
+	for _, f := range iconFallbacks {
+		if strings.Contains(wType, f.word) {
+			icon = f.icon
+			break
+		}
+	}
+	log.Printf("weather type (%v) is drawn as %v\n", wType, icon)
+	return icon
+}
+
+func (r *Renderer) drawIcon(filename string, size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	img, err := r.sprite(filename, size)
+	if err != nil {
+		return
+	}
+
+	dp := image.Pt(x, y)
+	rect := image.Rectangle{dp, dp.Add(img.Bounds().Size())}
+	draw.Draw(m, rect, img, img.Bounds().Min, draw.Over)
+	next = fixed.P(rect.Max.X, rect.Max.Y)
+	return
+}
+
+// drawFog draws bars of mist over a cloud.
+func (r *Renderer) drawFog(size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	next, err = r.drawIcon(iconFiles["くもり"], size, m, x, y)
+	if err != nil {
+		return
+	}
+	s := int(size)
+	mist := color.NRGBA{240, 240, 240, 220}
+	for i := 0; i < 3; i++ {
+		top := y + s*(58+14*i)/100
+		left := x + s*(5+10*(i%2))/100
+		fillRect(m, image.Rect(left, top, next.X.Ceil()-s*(15-10*(i%2))/100, top+s/14), mist)
+	}
+	return
+}
+
+// drawSleet draws a small snow over rain.
+func (r *Renderer) drawSleet(size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	next, err = r.drawIcon(iconFiles["雨"], size, m, x, y)
+	if err != nil {
+		return
+	}
+	s := int(size)
+	_, err = r.drawIcon(iconFiles["雪"], size/2, m, x+s/4, y+s/2)
+	return
+}
+
+// drawRainOrSnow draws rain and snow on both sides of a slash.
+func (r *Renderer) drawRainOrSnow(size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	s := int(size)
+	_, err = r.drawIcon(iconFiles["雨"], size*3/5, m, x, y)
+	if err != nil {
+		return
+	}
+	white := color.RGBA{255, 255, 255, 255}
+	for dy := s / 10; dy < s*9/10; dy++ {
+		dx := s*7/10 - (dy-s/10)*s*4/10/(s*8/10)
+		fillRect(m, image.Rect(x+dx-1, y+dy, x+dx+2, y+dy+1), white)
+	}
+	next, err = r.drawIcon(iconFiles["雪"], size*3/5, m, x+s*2/5, y+s*2/5)
+	return
+}
+
+// drawStorm draws gusts over a cloud.
+func (r *Renderer) drawStorm(size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	next, err = r.drawIcon(iconFiles["くもり"], size, m, x, y)
+	if err != nil {
+		return
+	}
+	s := int(size)
+	gust := color.RGBA{0, 60, 140, 255}
+	for i, w := range []int{45, 60, 35} {
+		top := y + s*(62+11*i)/100
+		left := x + s*(15+10*(i%2))/100
+		fillRect(m, image.Rect(left, top, left+s*w/100, top+s/20), gust)
+	}
+	return
+}
+
+// drawWeather draws the icon of wType whose height is size.
+func (r *Renderer) drawWeather(wType string, size uint, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	icon := iconFor(wType)
+	if f, ok := composites[icon]; ok {
+		return f(r, size, m, x, y)
+	}
+	return r.drawIcon(iconFiles[icon], size, m, x, y)
+}
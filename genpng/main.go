@@ -7,50 +7,35 @@ import (
 	"image/draw"
 	"image/png"
 	"io"
-	"io/ioutil"
 	"log"
 
 	"golang.org/x/image/font"
 	"golang.org/x/image/math/fixed"
 
 	"github.com/golang/freetype"
-	"github.com/nfnt/resize"
 
-	"github.com/bamchoh/bam-weather/assets"
+	"github.com/bamchoh/bam-weather/temp"
 )
 
-func resizeImg(filename string, size uint) (*image.Image, error) {
-	src, err := assets.Assets.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer src.Close()
-
-	img, _, err := image.Decode(src)
-	if err != nil {
-		return nil, err
+// drawArrow draws a small triangle pointing up when trend is positive
+// and down when negative, whose left top is at (x, y).
+func drawArrow(m draw.Image, x, y, trend int, c color.Color) {
+	const w, h = 12, 10
+	for dy := 0; dy < h; dy++ {
+		// half width of the row
+		half := (dy*w/2 + h/2) / h
+		if trend < 0 {
+			half = ((h-1-dy)*w/2 + h/2) / h
+		}
+		for dx := w/2 - half; dx <= w/2+half; dx++ {
+			m.Set(x+dx, y+dy, c)
+		}
 	}
-
-	img = resize.Resize(0, size, img, resize.Lanczos3)
-	return &img, nil
 }
 
-func generateTemp(m draw.Image, x, y int, low, high string) (err error) {
+func (r *Renderer) generateTemp(m draw.Image, x, y int, info WeatherInfo) (err error) {
 	var size float64 = 36
-	file, err := assets.Assets.Open("/assets/AmeChanPopMaruTTFLight-Regular.ttf")
-	if err != nil {
-		log.Println(err)
-		return
-	}
-
-	// Read the font data.
-	var fontBytes []byte
-	fontBytes, err = ioutil.ReadAll(file)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	f, err := freetype.ParseFont(fontBytes)
+	f, err := r.loadFont()
 	if err != nil {
 		log.Println(err)
 		return
@@ -67,80 +52,82 @@ func generateTemp(m draw.Image, x, y int, low, high string) (err error) {
 
 	pt := freetype.Pt(x, y+int(c.PointToFixed(size)>>6))
 	c.SetSrc(image.NewUniform(color.RGBA{255, 255, 255, 255}))
-	next, err = c.DrawString("H:", pt)
+	high := info.HighLabel
+	if high == "" {
+		high = "H:"
+	}
+	next, err = c.DrawString(high, pt)
 	if err != nil {
 		return
 	}
 
 	pt.X = next.X
 	c.SetSrc(image.NewUniform(color.RGBA{255, 0, 0, 255}))
-	next, err = c.DrawString(fmt.Sprintf("%s°", high), pt)
+	next, err = c.DrawString(info.High.Format(info.Unit), pt)
+	if err != nil {
+		return
+	}
+	// the arrow goes under the degree sign
+	if info.HighTrend != 0 {
+		drawArrow(m, next.X.Ceil()+1, y+22, info.HighTrend, color.RGBA{255, 0, 0, 255})
+	}
+	next, err = c.DrawString("°", next)
 	if err != nil {
 		return
 	}
 
 	pt.X = next.X + fixed.I(10)
 	c.SetSrc(image.NewUniform(color.RGBA{255, 255, 255, 255}))
-	next, err = c.DrawString("L:", pt)
+	low := info.LowLabel
+	if low == "" {
+		low = "L:"
+	}
+	next, err = c.DrawString(low, pt)
 	if err != nil {
 		return
 	}
 
 	pt.X = next.X
 	c.SetSrc(image.NewUniform(color.RGBA{0, 0, 255, 255}))
-	next, err = c.DrawString(fmt.Sprintf("%s°", low), pt)
+	next, err = c.DrawString(info.Low.Format(info.Unit), pt)
 	if err != nil {
 		return
 	}
-
-	return
-}
-
-func generateWeather(wType string, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
-	var filename = ""
-	switch wType {
-	case "雨":
-		filename = "/assets/rain.png"
-	case "晴れ":
-		filename = "/assets/sun.png"
-	case "雪":
-		filename = "/assets/snow.png"
-	case "くもり":
-		filename = "/assets/cloud.png"
-	case "雷":
-		filename = "/assets/thunder.png"
-	default:
-		err = fmt.Errorf("weather type (%v) is not supported", wType)
-		return
+	// the arrow goes under the degree sign
+	if info.LowTrend != 0 {
+		drawArrow(m, next.X.Ceil()+1, y+22, info.LowTrend, color.RGBA{0, 0, 255, 255})
 	}
-	img, err := resizeImg(filename, 100)
+	next, err = c.DrawString("°", next)
 	if err != nil {
 		return
 	}
 
-	dp := image.Pt(x, y)
-	r := image.Rectangle{dp, dp.Add((*img).Bounds().Size())}
-	draw.Draw(m, r, *img, image.ZP, draw.Over)
-	next = fixed.P(r.Max.X, r.Max.Y)
 	return
 }
 
-func generateConnection(text string, rgba color.RGBA, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
-	var size float64 = 24
-	file, err := assets.Assets.Open("/assets/AmeChanPopMaruTTFLight-Regular.ttf")
+// CanDraw reports whether the font has the glyphs of all characters of s.
+func (r *Renderer) CanDraw(s string) bool {
+	f, err := r.loadFont()
 	if err != nil {
 		log.Println(err)
-		return
+		return false
 	}
 
-	// Read the font data.
-	var fontBytes []byte
-	fontBytes, err = ioutil.ReadAll(file)
-	if err != nil {
-		log.Println(err)
-		return
+	for _, c := range s {
+		if c != ' ' && f.Index(c) == 0 {
+			return false
+		}
 	}
-	f, err := freetype.ParseFont(fontBytes)
+	return true
+}
+
+// CanDraw reports whether the font of the package functions can draw s.
+func CanDraw(s string) bool {
+	return defaultRenderer.CanDraw(s)
+}
+
+func (r *Renderer) drawString(text string, size float64, rgba color.RGBA, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	f, err := r.loadFont()
 	if err != nil {
 		log.Println(err)
 		return
@@ -159,53 +146,180 @@ func generateConnection(text string, rgba color.RGBA, m draw.Image, x, y int) (n
 	return c.DrawString(text, pt)
 }
 
+// WeatherInfo is the daily weather image. First, Third and Fifth are
+// the weathers and Second and Fourth are the connectors before
+// Third and Fifth, e.g. 晴れ, 時々, くもり, 一時, 雨.
 type WeatherInfo struct {
 	First  string
 	Second string
 	Third  string
-	Low    string
-	High   string
+	Fourth string
+	Fifth  string
+	Low    temp.Temp
+	High   temp.Temp
+	Unit   temp.Unit
+	// LowTrend and HighTrend are 1 when higher than the day before,
+	// -1 when lower and 0 when the same or unknown.
+	LowTrend  int
+	HighTrend int
+	POP       [4]int
+	Wind      string
+	// HighLabel and LowLabel are drawn before the temperatures,
+	// H: and L: when they are empty.
+	HighLabel string
+	LowLabel  string
+	// Look is the background, the sky of First.
+	Look Look
+	// Alt is the alt text of the image in the language of the labels,
+	// AltText makes it in Japanese when it is empty.
+	Alt string
+	// Advice are the names of the pictograms drawn under the weather,
+	// e.g. umbrella. Unknown names are skipped.
+	Advice []string
 }
 
-func generateWeatherImage(info WeatherInfo, m draw.Image, x, y int) (err error) {
-	var next fixed.Point26_6
-	next, err = generateWeather(info.First, m, x, y)
+func (info WeatherInfo) hasPOP() bool {
+	for _, p := range info.POP {
+		if p >= 0 {
+			return true
+		}
+	}
+	return false
+}
 
-	if err != nil {
-		return err
+func (r *Renderer) generatePOP(pop [4]int, m draw.Image, x, y int) (next fixed.Point26_6, err error) {
+	white := color.RGBA{255, 255, 255, 255}
+	track := image.NewUniform(color.NRGBA{255, 255, 255, 120})
+	fill := image.NewUniform(color.RGBA{0, 80, 200, 255})
+	cellW := 62
+	barW := 56
+	for i, p := range pop {
+		cx := x + i*cellW
+		text := "--"
+		if p >= 0 {
+			text = fmt.Sprintf("%d%%", p)
+		}
+		_, err = r.drawString(text, 14, white, m, cx+15, y)
+		if err != nil {
+			return
+		}
+
+		r := image.Rect(cx, y+18, cx+barW, y+24)
+		draw.Draw(m, r, track, image.ZP, draw.Over)
+		if p > 0 {
+			r.Max.X = r.Min.X + barW*p/100
+			draw.Draw(m, r, fill, image.ZP, draw.Over)
+		}
+	}
+	next = fixed.P(x+len(pop)*cellW, y+30)
+	return
+}
+
+// segments returns the weathers and the connectors before the second and
+// later weathers. A weather which is empty is left out with its connector.
+func (info WeatherInfo) segments() (weathers, connectors []string) {
+	weathers = []string{info.First}
+	for _, s := range [][2]string{{info.Second, info.Third}, {info.Fourth, info.Fifth}} {
+		if s[1] == "" {
+			continue
+		}
+		connectors = append(connectors, s[0])
+		weathers = append(weathers, s[1])
+	}
+	return
+}
+
+// generateWeatherImage draws the weathers and the temperatures.
+// Icons and connectors which fail to be drawn are logged and left blank,
+// so that the image is always made.
+func (r *Renderer) generateWeatherImage(info WeatherInfo, m draw.Image, x, y int) (err error) {
+	weathers, connectors := info.segments()
+	// three weathers are made smaller to fit in the width
+	var size uint = 100
+	var fontSize float64 = 24
+	if len(weathers) > 2 {
+		size, fontSize = 56, 18
 	}
+	top := y + (100-int(size))/2
+
+	next := fixed.P(x, top)
+	for i, w := range weathers {
+		if i > 0 && connectors[i-1] != "" {
+			rgba := color.RGBA{255, 255, 255, 255}
+			p, err := r.drawString(connectors[i-1], fontSize, rgba, m, next.X.Ceil(), top+int(size)/2)
+			if err != nil {
+				log.Println(err)
+			} else {
+				next.X = p.X
+			}
+		}
 
-	if info.Second != "" {
-		rgba := color.RGBA{255, 255, 255, 255}
-		next, err = generateConnection(info.Second, rgba, m, next.X.Ceil(), y+50)
+		p, err := r.drawWeather(w, size, m, next.X.Ceil(), top)
+		if err != nil {
+			log.Println(err)
+			p.X = next.X + fixed.I(int(size))
+		}
+		next.X = p.X
+	}
+	next.Y = fixed.I(y + 100)
+
+	if info.hasPOP() {
+		next, err = r.generatePOP(info.POP, m, x, next.Y.Ceil()+2)
 		if err != nil {
 			return err
 		}
+	}
+
+	err = r.generateTemp(m, x, next.Y.Ceil(), info)
+	if err != nil {
+		return err
+	}
 
-		next, err = generateWeather(info.Third, m, next.X.Ceil(), y)
+	y = next.Y.Ceil() + 42
+	if info.Wind != "" {
+		_, err = r.drawString(info.Wind, 16, color.RGBA{255, 255, 255, 255}, m, x, y)
 		if err != nil {
 			return err
 		}
+		y += 24
 	}
 
-	return generateTemp(m, x, next.Y.Ceil(), info.Low, info.High)
+	if len(info.Advice) > 0 {
+		drawPictograms(info.Advice, m, x, y+4)
+	}
+	return nil
 }
 
+// Generate writes the daily weather image of info in PNG.
 func Generate(info WeatherInfo, buffer io.Writer) error {
+	return defaultRenderer.Generate(info, buffer)
+}
+
+// Generate writes the daily weather image of info in PNG.
+func (r *Renderer) Generate(info WeatherInfo, buffer io.Writer) error {
 	var err error
 	w := 300
 	h := 175
+	if info.hasPOP() {
+		h += 32
+	}
+	if info.Wind != "" {
+		h += 24
+	}
+	if len(info.Advice) > 0 {
+		h += pictogramSize + 8
+	}
 	x := 0
 	y := 0
 	m := image.NewRGBA(image.Rect(x, y, w, h))
 
-	bg := image.NewUniform(color.RGBA{0, 200, 255, 255})
-	draw.Draw(m, m.Bounds(), bg, image.ZP, draw.Src)
+	info.Look.paint(m, m.Bounds(), info.First)
 
-	err = generateWeatherImage(info, m, x+25, y+20)
+	err = r.generateWeatherImage(info, m, x+25, y+20)
 	if err != nil {
 		return err
 	}
 
+	info.Look.paintAccent(m)
 	return png.Encode(buffer, m)
 }
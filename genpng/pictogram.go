@@ -0,0 +1,86 @@
+package genpng
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// pictogramSize is the width and height of a pictogram.
+const pictogramSize = 24
+
+// pictograms draw the advice by name at (x, y), the left top.
+var pictograms = map[string]func(m draw.Image, x, y int, c color.Color){
+	"umbrella": func(m draw.Image, x, y int, c color.Color) {
+		drawUmbrella(m, x, y, 11, c)
+	},
+	"folding_umbrella": func(m draw.Image, x, y int, c color.Color) {
+		drawUmbrella(m, x, y, 7, c)
+	},
+	"coat": func(m draw.Image, x, y int, c color.Color) {
+		drawClothes(m, x, y, 16, 20, c)
+	},
+	"layer": func(m draw.Image, x, y int, c color.Color) {
+		drawClothes(m, x+3, y-2, 5, 14, c)
+		drawClothes(m, x-2, y+3, 5, 14, c)
+	},
+	"short_sleeves": func(m draw.Image, x, y int, c color.Color) {
+		drawClothes(m, x, y, 5, 16, c)
+	},
+	"laundry": func(m draw.Image, x, y int, c color.Color) {
+		fillRect(m, image.Rect(x, y+2, x+pictogramSize, y+3), c)
+		drawClothes(m, x, y+3, 5, 14, c)
+	},
+	"indoor_laundry": func(m draw.Image, x, y int, c color.Color) {
+		// the roof over the laundry
+		for dy := 0; dy < 6; dy++ {
+			fillRect(m, image.Rect(x+12-2*dy, y+dy, x+12+2*dy, y+dy+1), c)
+		}
+		drawClothes(m, x+3, y+8, 4, 12, c)
+	},
+}
+
+func fillRect(m draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(m, r, image.NewUniform(c), image.ZP, draw.Over)
+}
+
+// drawUmbrella draws an umbrella whose canopy has the radius r.
+func drawUmbrella(m draw.Image, x, y, r int, c color.Color) {
+	cx, cy := x+pictogramSize/2, y+12
+	for dy := -r; dy <= 0; dy++ {
+		for dx := -r; dx <= r; dx++ {
+			if dx*dx+dy*dy <= r*r {
+				m.Set(cx+dx, cy+dy, c)
+			}
+		}
+	}
+	// the shaft and the hook
+	fillRect(m, image.Rect(cx, cy, cx+2, cy+r), c)
+	fillRect(m, image.Rect(cx-3, cy+r-2, cx-1, cy+r), c)
+	fillRect(m, image.Rect(cx-3, cy+r, cx+2, cy+r+2), c)
+}
+
+// drawClothes draws a shirt whose sleeves are sleeve long
+// and whose body is length long.
+func drawClothes(m draw.Image, x, y, sleeve, length int, c color.Color) {
+	const w, arm = 10, 5
+	left := x + (pictogramSize-w)/2
+	fillRect(m, image.Rect(left, y+2, left+w, y+2+length), c)
+	fillRect(m, image.Rect(left-arm, y+2, left, y+2+sleeve), c)
+	fillRect(m, image.Rect(left+w, y+2, left+w+arm, y+2+sleeve), c)
+}
+
+// drawPictograms draws the pictograms of the advice in a row
+// and returns the right end.
+func drawPictograms(names []string, m draw.Image, x, y int) int {
+	white := color.RGBA{255, 255, 255, 255}
+	for _, name := range names {
+		f, ok := pictograms[name]
+		if !ok {
+			continue
+		}
+		f(m, x, y, white)
+		x += pictogramSize + 8
+	}
+	return x
+}
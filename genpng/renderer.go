@@ -0,0 +1,130 @@
+package genpng
+
+import (
+	"image"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/freetype"
+	"github.com/golang/freetype/truetype"
+	"github.com/nfnt/resize"
+
+	"github.com/bamchoh/bam-weather/assets"
+)
+
+const fontFile = "/assets/AmeChanPopMaruTTFLight-Regular.ttf"
+
+// Renderer draws the images. The font is parsed and the icons are decoded
+// and scaled once, and then shared by the images it draws.
+// It is safe for concurrent use.
+type Renderer struct {
+	fontOnce sync.Once
+	font     *truetype.Font
+	fontErr  error
+
+	mu      sync.Mutex
+	sprites map[spriteKey]*sprite
+}
+
+type spriteKey struct {
+	filename string
+	// size is the height, 0 for the image as decoded
+	size uint
+}
+
+type sprite struct {
+	once sync.Once
+	img  image.Image
+	err  error
+}
+
+// NewRenderer returns a renderer which loads the font and the icons
+// when they are drawn first.
+func NewRenderer() *Renderer {
+	return &Renderer{sprites: map[spriteKey]*sprite{}}
+}
+
+// defaultRenderer draws the images of the package functions.
+var defaultRenderer = NewRenderer()
+
+// preloadSizes are the heights of the icons in the daily, weekly and
+// hourly images.
+var preloadSizes = []uint{100, 56, 44}
+
+// Preload loads the font and scales the icons to the sizes of the images,
+// so that the first image does not wait for them.
+func (r *Renderer) Preload() error {
+	if _, err := r.loadFont(); err != nil {
+		return err
+	}
+	for _, filename := range iconFiles {
+		for _, size := range preloadSizes {
+			if _, err := r.sprite(filename, size); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Preload loads the font and the icons of the package functions.
+func Preload() error {
+	return defaultRenderer.Preload()
+}
+
+func (r *Renderer) loadFont() (*truetype.Font, error) {
+	r.fontOnce.Do(func() {
+		file, err := assets.Assets.Open(fontFile)
+		if err != nil {
+			r.fontErr = err
+			return
+		}
+		defer file.Close()
+
+		fontBytes, err := ioutil.ReadAll(file)
+		if err != nil {
+			r.fontErr = err
+			return
+		}
+		r.font, r.fontErr = freetype.ParseFont(fontBytes)
+	})
+	return r.font, r.fontErr
+}
+
+// sprite returns the asset scaled to the height size, keeping the aspect ratio.
+// The decoded asset and the scaled one are kept for later calls.
+func (r *Renderer) sprite(filename string, size uint) (image.Image, error) {
+	key := spriteKey{filename, size}
+	r.mu.Lock()
+	s, ok := r.sprites[key]
+	if !ok {
+		s = &sprite{}
+		r.sprites[key] = s
+	}
+	r.mu.Unlock()
+
+	s.once.Do(func() {
+		if size == 0 {
+			s.img, s.err = decodeAsset(filename)
+			return
+		}
+		var src image.Image
+		src, s.err = r.sprite(filename, 0)
+		if s.err != nil {
+			return
+		}
+		s.img = resize.Resize(0, size, src, resize.Lanczos3)
+	})
+	return s.img, s.err
+}
+
+func decodeAsset(filename string) (image.Image, error) {
+	src, err := assets.Assets.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	img, _, err := image.Decode(src)
+	return img, err
+}
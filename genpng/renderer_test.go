@@ -0,0 +1,126 @@
+package genpng
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+var (
+	testInfo = WeatherInfo{
+		First:  "晴れ",
+		Second: "のち",
+		Third:  "くもり",
+		High:   temp.New(18, temp.Celsius),
+		Low:    temp.New(9, temp.Celsius),
+		POP:    [4]int{0, 10, 20, 30},
+		Wind:   "北の風のち南の風",
+	}
+	testDays = []WeeklyDay{
+		{Label: "10日(木)", Weather: "晴れ", POP: 10, High: temp.New(25, temp.Celsius), Low: temp.New(18, temp.Celsius)},
+		{Label: "11日(金)", Weather: "くもり", POP: 30, High: temp.New(23, temp.Celsius), Low: temp.New(17, temp.Celsius)},
+		{Label: "12日(土)", Weather: "雨", POP: 80, High: temp.New(20, temp.Celsius), Low: temp.New(16, temp.Celsius)},
+		{Label: "13日(日)", Weather: "雪", POP: 60, High: temp.New(5, temp.Celsius), Low: temp.New(-1, temp.Celsius)},
+		{Label: "14日(月)", Weather: "雷", POP: 50, High: temp.New(22, temp.Celsius), Low: temp.New(15, temp.Celsius)},
+		{Label: "15日(火)", Weather: "霧", POP: 20, High: temp.New(21, temp.Celsius), Low: temp.New(14, temp.Celsius)},
+		{Label: "16日(水)", Weather: "みぞれ", POP: 70, High: temp.New(3, temp.Celsius), Low: temp.New(0, temp.Celsius)},
+	}
+	testChart = HourlyChart{
+		Slots: []HourlySlot{
+			{Label: "6時", Weather: "晴れ", Temp: temp.New(12, temp.Celsius)},
+			{Label: "9時", Weather: "晴れ", Temp: temp.New(15, temp.Celsius)},
+			{Label: "12時", Weather: "くもり", Temp: temp.New(18, temp.Celsius)},
+			{Label: "15時", Weather: "雨", Temp: temp.New(17, temp.Celsius)},
+		},
+		Unit: temp.Celsius,
+	}
+)
+
+// drawAll draws every kind of image by r.
+func drawAll(r *Renderer) ([][]byte, error) {
+	draws := []func(b *bytes.Buffer) error{
+		func(b *bytes.Buffer) error { return r.Generate(testInfo, b) },
+		func(b *bytes.Buffer) error { return r.GenerateWeekly(testDays, Look{}, b) },
+		func(b *bytes.Buffer) error { return r.GenerateHourly(testChart, b) },
+	}
+	var images [][]byte
+	for _, draw := range draws {
+		var b bytes.Buffer
+		if err := draw(&b); err != nil {
+			return nil, err
+		}
+		images = append(images, b.Bytes())
+	}
+	return images, nil
+}
+
+// TestRendererParallel draws with a shared renderer from goroutines,
+// which is meant to be run with -race.
+func TestRendererParallel(t *testing.T) {
+	want, err := drawAll(NewRenderer())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRenderer()
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := drawAll(r)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for j := range got {
+				if !bytes.Equal(got[j], want[j]) {
+					t.Errorf("image %d differs from the one of a new renderer", j)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+// The cold benchmarks use a new renderer for each image as on a cold start,
+// the warm ones share a preloaded renderer as on warm starts.
+
+func benchmarkGenerate(b *testing.B, warm bool, draw func(r *Renderer) error) {
+	shared := NewRenderer()
+	if err := shared.Preload(); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := shared
+		if !warm {
+			r = NewRenderer()
+		}
+		if err := draw(r); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func generateDaily(r *Renderer) error {
+	return r.Generate(testInfo, ioutil.Discard)
+}
+
+func generateWeekly(r *Renderer) error {
+	return r.GenerateWeekly(testDays, Look{}, ioutil.Discard)
+}
+
+func BenchmarkGenerateDailyCold(b *testing.B)  { benchmarkGenerate(b, false, generateDaily) }
+func BenchmarkGenerateDailyWarm(b *testing.B)  { benchmarkGenerate(b, true, generateDaily) }
+func BenchmarkGenerateWeeklyCold(b *testing.B) { benchmarkGenerate(b, false, generateWeekly) }
+func BenchmarkGenerateWeeklyWarm(b *testing.B) { benchmarkGenerate(b, true, generateWeekly) }
@@ -0,0 +1,228 @@
+package genpng
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Names of the built-in themes.
+const (
+	Flat = "flat"
+	Sky  = "sky"
+)
+
+// Color is a color written as #rrggbb in JSON.
+type Color color.RGBA
+
+func (c *Color) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	var r, g, bl uint8
+	if _, err := fmt.Sscanf(strings.ToLower(s), "#%02x%02x%02x", &r, &g, &bl); err != nil || len(s) != 7 {
+		return fmt.Errorf("color (%v) is not #rrggbb", s)
+	}
+	*c = Color{r, g, bl, 255}
+	return nil
+}
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B))
+}
+
+// Gradient is a vertical gradient from Top to Bottom.
+type Gradient struct {
+	Top    Color `json:"top"`
+	Bottom Color `json:"bottom"`
+}
+
+// paint fills r with the gradient.
+func (g Gradient) paint(m draw.Image, r image.Rectangle) {
+	h := r.Dy() - 1
+	if h < 1 {
+		h = 1
+	}
+	lerp := func(a, b uint8, i int) uint8 {
+		return uint8((int(a)*(h-i) + int(b)*i) / h)
+	}
+	for i := 0; i < r.Dy(); i++ {
+		c := color.RGBA{
+			lerp(g.Top.R, g.Bottom.R, i),
+			lerp(g.Top.G, g.Bottom.G, i),
+			lerp(g.Top.B, g.Bottom.B, i),
+			255,
+		}
+		row := image.Rect(r.Min.X, r.Min.Y+i, r.Max.X, r.Min.Y+i+1)
+		draw.Draw(m, row, image.NewUniform(c), image.ZP, draw.Src)
+	}
+}
+
+// Theme is the backgrounds of the images.
+// Skies are the gradients by weather, whose keys are sunny, cloudy, rain,
+// snow, thunder, fog, sleet, rain_or_snow and storm, and Default is used
+// for the others. Night is used instead at night when it is set.
+// Accents are the colors of the band at the bottom by season, whose keys
+// are spring, summer, autumn and winter.
+type Theme struct {
+	Name    string              `json:"name"`
+	Default Gradient            `json:"default"`
+	Skies   map[string]Gradient `json:"skies"`
+	Night   *Gradient           `json:"night"`
+	Accents map[string]Color    `json:"accents"`
+}
+
+// skyNames are the keys of Skies by icon.
+var skyNames = map[string]string{
+	"晴れ":  "sunny",
+	"くもり": "cloudy",
+	"雨":   "rain",
+	"雪":   "snow",
+	"雷":   "thunder",
+	"霧":   "fog",
+	"みぞれ": "sleet",
+	"雨か雪": "rain_or_snow",
+	"暴風":  "storm",
+}
+
+// season returns the season of month, empty for 0.
+func season(month time.Month) string {
+	switch month {
+	case time.March, time.April, time.May:
+		return "spring"
+	case time.June, time.July, time.August:
+		return "summer"
+	case time.September, time.October, time.November:
+		return "autumn"
+	case time.December, time.January, time.February:
+		return "winter"
+	}
+	return ""
+}
+
+// accentHeight is the height of the seasonal band.
+const accentHeight = 6
+
+// Look selects the background of an image from Theme.
+// Night chooses the night palette and Month the seasonal accent,
+// which is not drawn when Month is 0. A nil Theme is the flat theme.
+type Look struct {
+	Theme *Theme
+	Night bool
+	Month time.Month
+}
+
+// sky returns the gradient of the weather.
+func (l Look) sky(weather string) Gradient {
+	t := l.Theme
+	if t == nil {
+		t = builtinThemes[Flat]
+	}
+	if l.Night && t.Night != nil {
+		return *t.Night
+	}
+	if len(t.Skies) == 0 || weather == "" {
+		return t.Default
+	}
+	if g, ok := t.Skies[skyNames[iconFor(weather)]]; ok {
+		return g
+	}
+	return t.Default
+}
+
+// paint paints r with the sky of the weather.
+func (l Look) paint(m draw.Image, r image.Rectangle, weather string) {
+	l.sky(weather).paint(m, r)
+}
+
+// paintAccent draws the seasonal band at the bottom of m.
+func (l Look) paintAccent(m draw.Image) {
+	if l.Theme == nil {
+		return
+	}
+	c, ok := l.Theme.Accents[season(l.Month)]
+	if !ok {
+		return
+	}
+	b := m.Bounds()
+	fillRect(m, image.Rect(b.Min.X, b.Max.Y-accentHeight, b.Max.X, b.Max.Y), color.RGBA(c))
+}
+
+// ReadTheme decodes the theme in JSON.
+func ReadTheme(r io.Reader) (*Theme, error) {
+	t := &Theme{}
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, err
+	}
+	if t.Name == "" {
+		return nil, fmt.Errorf("theme has no name")
+	}
+	return t, nil
+}
+
+// LoadTheme reads the theme from the file.
+func LoadTheme(name string) (*Theme, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadTheme(f)
+}
+
+// Themes returns the built-in themes by name.
+func Themes() map[string]*Theme {
+	m := map[string]*Theme{}
+	for name, t := range builtinThemes {
+		m[name] = t
+	}
+	return m
+}
+
+var builtinThemes = func() map[string]*Theme {
+	m := map[string]*Theme{}
+	for _, s := range []string{flatJSON, skyJSON} {
+		t, err := ReadTheme(strings.NewReader(s))
+		if err != nil {
+			panic(fmt.Sprintf("built-in theme: %v", err))
+		}
+		m[t.Name] = t
+	}
+	return m
+}()
+
+// flatJSON is the plain sky of the images before themes.
+const flatJSON = `{
+  "name": "flat",
+  "default": {"top": "#00c8ff", "bottom": "#00c8ff"}
+}`
+
+const skyJSON = `{
+  "name": "sky",
+  "default": {"top": "#00a0f0", "bottom": "#60d8ff"},
+  "skies": {
+    "sunny": {"top": "#1e82e6", "bottom": "#78d2ff"},
+    "cloudy": {"top": "#788ca0", "bottom": "#a8bccc"},
+    "rain": {"top": "#3c5070", "bottom": "#6e82a0"},
+    "snow": {"top": "#6e8cb4", "bottom": "#a0b8d4"},
+    "thunder": {"top": "#323250", "bottom": "#64648c"},
+    "fog": {"top": "#8c96a0", "bottom": "#b4bac0"},
+    "sleet": {"top": "#5a6e8c", "bottom": "#96a5be"},
+    "rain_or_snow": {"top": "#5a6e8c", "bottom": "#96a5be"},
+    "storm": {"top": "#283c50", "bottom": "#5a6e82"}
+  },
+  "night": {"top": "#0a143c", "bottom": "#283c78"},
+  "accents": {
+    "spring": "#ffaac8",
+    "summer": "#ffdc00",
+    "autumn": "#dc6e1e",
+    "winter": "#e6f0ff"
+  }
+}`
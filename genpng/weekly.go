@@ -0,0 +1,92 @@
+package genpng
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+type WeeklyDay struct {
+	Label       string
+	Weather     string
+	POP         int
+	Low         temp.Temp
+	High        temp.Temp
+	Unit        temp.Unit
+	Reliability string
+}
+
+func (r *Renderer) generateWeeklyDay(day WeeklyDay, m draw.Image, x, y int) (err error) {
+	white := color.RGBA{255, 255, 255, 255}
+	_, err = r.drawString(day.Label, 18, white, m, x+5, y)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.drawWeather(day.Weather, 56, m, x+5, y+30)
+	if err != nil {
+		return err
+	}
+
+	pop := "--%"
+	if day.POP >= 0 {
+		pop = fmt.Sprintf("%d%%", day.POP)
+	}
+	_, err = r.drawString(pop, 18, white, m, x+15, y+95)
+	if err != nil {
+		return err
+	}
+
+	if day.High.Valid() {
+		_, err = r.drawString(fmt.Sprintf("%s°", day.High.Format(day.Unit)), 20, color.RGBA{255, 0, 0, 255}, m, x+20, y+120)
+		if err != nil {
+			return err
+		}
+	}
+
+	if day.Low.Valid() {
+		_, err = r.drawString(fmt.Sprintf("%s°", day.Low.Format(day.Unit)), 20, color.RGBA{0, 0, 255, 255}, m, x+20, y+145)
+		if err != nil {
+			return err
+		}
+	}
+
+	if day.Reliability != "" {
+		_, err = r.drawString(day.Reliability, 14, white, m, x+70, y+100)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateWeekly writes the weekly weather image of days in PNG.
+// Each day has the sky of its weather.
+func GenerateWeekly(days []WeeklyDay, look Look, buffer io.Writer) error {
+	return defaultRenderer.GenerateWeekly(days, look, buffer)
+}
+
+// GenerateWeekly writes the weekly weather image of days in PNG.
+// Each day has the sky of its weather.
+func (r *Renderer) GenerateWeekly(days []WeeklyDay, look Look, buffer io.Writer) error {
+	colW := 90
+	w := colW * len(days)
+	h := 190
+	m := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for i, day := range days {
+		look.paint(m, image.Rect(i*colW, 0, (i+1)*colW, h), day.Weather)
+		err := r.generateWeeklyDay(day, m, i*colW, 10)
+		if err != nil {
+			return err
+		}
+	}
+
+	look.paintAccent(m)
+	return png.Encode(buffer, m)
+}
@@ -1,11 +1,12 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"log"
-	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 // APIData is API Data
@@ -21,29 +22,48 @@ type JmardbAPI struct {
 	Data []APIData
 }
 
-func getXMLLink(sday, eday time.Time) (string, error) {
+// JmardbSource searches reports with the jmardb-api.
+// AreaCode is the code of the 府県予報区 given as areacode_mete.
+type JmardbSource struct {
+	AreaCode string
+	Fetcher  *Fetcher
+}
+
+func (s *JmardbSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	return getXMLLink(ctx, s.Fetcher, title, s.AreaCode, sday, eday)
+}
+
+func (s *JmardbSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	return s.Fetcher.FetchReport(ctx, link, v)
+}
+
+func getXMLLink(ctx context.Context, f *Fetcher, title, areaCode string, sday, eday time.Time) ([]string, error) {
 	ssday := sday.Format("2006-01-02 15:04:05")
 	seday := eday.Format("2006-01-02 15:04:05")
 	v := url.Values{}
-	v.Set("title", "府県天気予報")
-	v.Add("areacode_mete", "270000")
+	v.Set("title", title)
+	v.Add("areacode_mete", areaCode)
 	v.Add("datetime", ssday)
 	v.Add("datetime", seday)
 	apiURL := `http://api.aitc.jp/jmardb-api/search`
 	fetchURL := apiURL + "?" + v.Encode()
 
-	log.Println("Fetch URL:", fetchURL)
-
-	resp, err := http.Get(fetchURL)
+	data, err := f.fetch(ctx, fetchURL)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	dec := json.NewDecoder(resp.Body)
 	var d JmardbAPI
-	if err := dec.Decode(&d); err != nil {
-		return "", err
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	if len(d.Data) == 0 {
+		return nil, errors.Wrap(errLinkNotFound, "getXMLLink")
+	}
+
+	var links []string
+	for _, data := range d.Data {
+		links = append(links, data.Link)
 	}
-	return d.Data[len(d.Data)-1].Link, nil
+	return links, nil
 }
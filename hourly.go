@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bamchoh/bam-weather/genpng"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+// HourlyForecast is the forecast of a 3-hour slot in 地域時系列予報.
+// WindSpeedLevel is -1 and Temp is missing when they are not forecasted.
+type HourlyForecast struct {
+	Time           time.Time
+	Weather        string
+	WindDirection  string
+	WindSpeedLevel int
+	Temp           temp.Temp
+}
+
+// newHourlyForecasts returns the 地域時系列予報 of the region on the same date as day.
+// The first series has weather and wind of the area,
+// the second one has temperatures of the station.
+func newHourlyForecasts(v *Report, region Region, day time.Time) ([]HourlyForecast, error) {
+	var hours []HourlyForecast
+	for _, info := range v.Body.MeteorologicalInfos {
+		if info.Type != "地域時系列予報" || len(info.TimeSeriesInfos) == 0 {
+			continue
+		}
+
+		series := info.TimeSeriesInfos[0]
+		item, ok := findAreaItem(series.Items, region.AreaCode)
+		if !ok {
+			return nil, fmt.Errorf("area (%v) was not found in hourly forecast", region.AreaCode)
+		}
+		for _, def := range series.TimeDefines {
+			tt, err := time.Parse(time.RFC3339, def.DateTime)
+			if err != nil {
+				return nil, err
+			}
+			if tt.Year() != day.Year() || tt.YearDay() != day.YearDay() {
+				continue
+			}
+			h := HourlyForecast{Time: tt, WindSpeedLevel: -1}
+			for _, kind := range item.Kinds {
+				switch kind.Type {
+				case "天気":
+					h.Weather = findRefValue(kind.Weathers, def.ID)
+				case "風":
+					h.WindDirection = findRefValue(kind.WindDirections, def.ID)
+					if level, err := strconv.Atoi(findRefValue(kind.WindSpeedLevels, def.ID)); err == nil {
+						h.WindSpeedLevel = level
+					}
+				}
+			}
+			hours = append(hours, h)
+		}
+
+		for _, series := range info.TimeSeriesInfos[1:] {
+			item, ok := findStationItem(series.Items, region.Station)
+			if !ok {
+				continue
+			}
+			for _, def := range series.TimeDefines {
+				tt, err := time.Parse(time.RFC3339, def.DateTime)
+				if err != nil {
+					return nil, err
+				}
+				for i := range hours {
+					if !hours[i].Time.Equal(tt) {
+						continue
+					}
+					hours[i].Temp, err = findTemperature(item, def.ID).Value()
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		break
+	}
+	return hours, nil
+}
+
+func genHourlyChart(hours []HourlyForecast, unit temp.Unit) genpng.HourlyChart {
+	chart := genpng.HourlyChart{Unit: unit}
+	for _, h := range hours {
+		chart.Slots = append(chart.Slots, genpng.HourlySlot{
+			Label:   h.Time.Format("15:04"),
+			Weather: primaryWeather(h.Weather),
+			Temp:    h.Temp,
+		})
+	}
+	return chart
+}
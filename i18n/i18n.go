@@ -0,0 +1,175 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bamchoh/bam-weather/advice"
+)
+
+// Word is a translation of a word of JMA reports.
+// A word with Before goes before the word preceding it, e.g. 夕方から is
+// "from evening" in English. A word with Attach is written without the
+// separator before it. A word with empty Text is dropped.
+type Word struct {
+	Text   string
+	Before bool
+	Attach bool
+}
+
+// Phrases are the sentence templates of a language in fmt format.
+// Intro takes the region, the day name, the date and the weather.
+// Comma separates clauses. Higher and Lower take the name of the compared day and the difference,
+// POP takes the period from which rain is likely and the highest
+// probability. WeeklyIntro and WarningIntro take the region,
+// WarningIssued and WarningCancelled take the name of a warning,
+// WarningUpgraded and WarningDowngraded take the name before and after.
+// Advice are the sentences of the advice items.
+type Phrases struct {
+	Intro       string
+	Today       string
+	Tomorrow    string
+	Comma       string
+	Lowest      string
+	Highest     string
+	Higher      string
+	Lower       string
+	Same        string
+	Unknown     string
+	POP         string
+	Periods     [4]string
+	Wind        string
+	Wave        string
+	Tag         string
+	Corrected   string
+	Cancelled   string
+	HighLabel   string
+	LowLabel    string
+	PageDaily   string
+	PageWeekly  string
+	PageWarning string
+	Advice      map[advice.Item]string
+
+	WeeklyIntro       string
+	WarningIntro      string
+	WarningIssued     string
+	WarningUpgraded   string
+	WarningDowngraded string
+	WarningCancelled  string
+}
+
+// Lang is a language which forecasts are translated into.
+// Sep separates words and End separates sentences.
+// DayFormat takes the month name, the month, the day and the weekday.
+type Lang struct {
+	Code      string
+	Sep       string
+	End       string
+	Weekdays  [7]string
+	Months    [12]string
+	DayFormat string
+	Phrases   Phrases
+	words     map[string]Word
+	longest   int
+}
+
+// Lookup returns the language of code (en, zh or ko).
+func Lookup(code string) (*Lang, bool) {
+	l, ok := langs[code]
+	return l, ok
+}
+
+// Day returns t in the language, e.g. Thu, Sep 10.
+func (l *Lang) Day(t time.Time) string {
+	return fmt.Sprintf(l.DayFormat, l.Months[t.Month()-1], int(t.Month()), t.Day(), l.Weekdays[t.Weekday()])
+}
+
+// Sentences joins sentences with End, the first letter of each is capitalized.
+func (l *Lang) Sentences(sentences []string) string {
+	var ss []string
+	for _, s := range sentences {
+		if s == "" {
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s)
+		ss = append(ss, string(unicode.ToUpper(r))+s[n:])
+	}
+	if len(ss) == 0 {
+		return ""
+	}
+	return strings.Join(ss, l.End) + strings.TrimSpace(l.End)
+}
+
+// narrow turns full-width ASCII such as ０．５ into ASCII.
+func narrow(r rune) rune {
+	switch {
+	case r == '　':
+		return ' '
+	case r >= '！' && r <= '～':
+		return r - '！' + '!'
+	}
+	return r
+}
+
+// Translate returns s translated word by word.
+// ok is false when s has a word which is not in the dictionary.
+// Words are matched longest first, spaces only separate words and
+// numbers are kept as they are.
+func (l *Lang) Translate(s string) (string, bool) {
+	s = strings.Map(narrow, s)
+	var words []Word
+	for i := 0; i < len(s); {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if r == ' ' {
+			i += n
+			continue
+		}
+		if r < utf8.RuneSelf {
+			j := i
+			for j < len(s) && s[j] != ' ' && s[j] < utf8.RuneSelf {
+				j++
+			}
+			words = append(words, Word{Text: s[i:j]})
+			i = j
+			continue
+		}
+
+		var w Word
+		found := 0
+		for k := l.longest; k > 0; k-- {
+			if i+k > len(s) {
+				continue
+			}
+			if v, ok := l.words[s[i:i+k]]; ok {
+				w = v
+				found = k
+				break
+			}
+		}
+		if found == 0 {
+			return "", false
+		}
+		i += found
+		if w.Text == "" {
+			continue
+		}
+		if w.Before && len(words) > 0 {
+			last := len(words) - 1
+			words = append(words[:last], w, words[last])
+			continue
+		}
+		words = append(words, w)
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		if b.Len() > 0 && !w.Attach {
+			b.WriteString(l.Sep)
+		}
+		b.WriteString(w.Text)
+	}
+	return b.String(), true
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import "testing"
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		in         string
+		en, zh, ko string
+	}{
+		// longest match: 雷を伴う, 北東 and 夜から are single words
+		{"くもり　時々　雨　で　雷を伴う", "cloudy, at times rain, with thunder", "多云，有时雨，伴有雷", "흐림 때때로 비, 천둥 동반"},
+		{"北東の風", "northeast wind", "东北风", "북동풍"},
+		{"夜から　雨", "from night rain", "夜间起雨", "밤부터 비"},
+		// words without separators
+		{"晴れ後くもり", "sunny, later cloudy", "晴，之后多云", "맑음 후 흐림"},
+		// Before puts "from" before the time, Attach joins to the word before
+		{"晴れ　夕方　から　くもり", "sunny from evening cloudy", "晴傍晚起多云", "맑음 저녁부터 흐림"},
+		// では is empty in Chinese and 降る in every language
+		{"山沿い　では　雪", "near the mountains: snow", "山区附近雪", "산간에서는 눈"},
+		{"雨　で　雷を伴い　激しく　降る", "rain, with thunder heavy", "雨，伴有雷强烈", "비, 천둥 동반 강하게"},
+		// numbers are kept and full-width characters narrowed
+		{"波　２メートル", "waves 2 m", "浪2米", "파도 2m"},
+		{"大雨警報", "heavy rain warning", "大雨警报", "큰비 경보"},
+	}
+	for _, tt := range tests {
+		for code, want := range map[string]string{"en": tt.en, "zh": tt.zh, "ko": tt.ko} {
+			l, _ := Lookup(code)
+			got, ok := l.Translate(tt.in)
+			if !ok || got != want {
+				t.Errorf("%s: Translate(%q) = %q, %v, want %q", code, tt.in, got, ok, want)
+			}
+		}
+	}
+}
+
+func TestTranslateUnknown(t *testing.T) {
+	l, _ := Lookup("en")
+	for _, s := range []string{"晴れ　ひょう", "未知"} {
+		if got, ok := l.Translate(s); ok {
+			t.Errorf("Translate(%q) = %q, want not ok", s, got)
+		}
+	}
+	if got, ok := l.Translate(""); !ok || got != "" {
+		t.Errorf("Translate(\"\") = %q, %v", got, ok)
+	}
+}
+
+func TestSentences(t *testing.T) {
+	en, _ := Lookup("en")
+	zh, _ := Lookup("zh")
+	tests := []struct {
+		l    *Lang
+		in   []string
+		want string
+	}{
+		{en, []string{"sunny", "from evening, cloudy"}, "Sunny. From evening, cloudy."},
+		{zh, []string{"晴", "傍晚起，多云"}, "晴。傍晚起，多云。"},
+	}
+	for _, tt := range tests {
+		if got := tt.l.Sentences(tt.in); got != tt.want {
+			t.Errorf("%s: Sentences(%q) = %q, want %q", tt.l.Code, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package i18n
+
+import "github.com/bamchoh/bam-weather/advice"
+
+var en = &Lang{
+	Code:      "en",
+	Sep:       " ",
+	End:       ". ",
+	Weekdays:  [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Months:    [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	DayFormat: "%[4]s, %[1]s %[3]d",
+	Phrases: Phrases{
+		Intro:       "%[1]s, %[2]s (%[3]s): %[4]s",
+		Today:       "today",
+		Tomorrow:    "tomorrow",
+		Comma:       ", ",
+		Lowest:      "Low %s",
+		Highest:     "High %s",
+		Higher:      "%[2]d° higher than %[1]s",
+		Lower:       "%[2]d° lower than %[1]s",
+		Same:        "same as %s",
+		Unknown:     "unknown",
+		POP:         "%[2]d%% chance of rain from %[1]s",
+		Periods:     [4]string{"overnight", "morning", "afternoon", "evening"},
+		Wind:        "Wind: %s",
+		Wave:        "Waves: %s",
+		Tag:         "#bam_weather",
+		Corrected:   "[Correction] ",
+		Cancelled:   "[Cancelled] The forecast for %s was withdrawn by JMA",
+		HighLabel:   "H:",
+		LowLabel:    "L:",
+		PageDaily:   "%s weather %s",
+		PageWeekly:  "%s weekly weather from %s",
+		PageWarning: "%s warnings %s",
+		Advice: map[advice.Item]string{
+			advice.Umbrella:        "Take an umbrella.",
+			advice.FoldingUmbrella: "A folding umbrella would be handy.",
+			advice.Coat:            "Wear a coat.",
+			advice.Layer:           "Dress in layers.",
+			advice.ShortSleeves:    "Short sleeves will do.",
+			advice.Laundry:         "Good day to dry laundry outside.",
+			advice.IndoorLaundry:   "Dry laundry indoors.",
+		},
+		WeeklyIntro:       "Weekly weather for %s",
+		WarningIntro:      "Warnings for %s",
+		WarningIssued:     "%s issued!",
+		WarningUpgraded:   "%s upgraded to %s!",
+		WarningDowngraded: "%s downgraded to %s",
+		WarningCancelled:  "%s lifted",
+	},
+}
+
+var zh = &Lang{
+	Code:      "zh",
+	Sep:       "",
+	End:       "。",
+	Weekdays:  [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+	DayFormat: "%[2]d月%[3]d日 %[4]s",
+	Phrases: Phrases{
+		Intro:       "%[1]s%[2]s(%[3]s)天气：%[4]s",
+		Today:       "今天",
+		Tomorrow:    "明天",
+		Comma:       "，",
+		Lowest:      "最低气温%s",
+		Highest:     "最高气温%s",
+		Higher:      "比%[1]s高%[2]d度",
+		Lower:       "比%[1]s低%[2]d度",
+		Same:        "和%s差不多",
+		Unknown:     "未知",
+		POP:         "%[1]s起降雨概率%[2]d%%",
+		Periods:     [4]string{"凌晨", "上午", "下午", "晚上"},
+		Wind:        "风：%s",
+		Wave:        "海浪：%s",
+		Tag:         "#bam_weather",
+		Corrected:   "【更正】",
+		Cancelled:   "【取消】%s的天气预报已被气象台撤回",
+		HighLabel:   "高:",
+		LowLabel:    "低:",
+		PageDaily:   "%s天气 %s",
+		PageWeekly:  "%s一周天气 %s起",
+		PageWarning: "%s气象警报 %s",
+		Advice: map[advice.Item]string{
+			advice.Umbrella:        "请带伞。",
+			advice.FoldingUmbrella: "最好带把折叠伞。",
+			advice.Coat:            "请穿外套。",
+			advice.Layer:           "建议穿便于增减的衣服。",
+			advice.ShortSleeves:    "穿短袖就可以。",
+			advice.Laundry:         "适合在室外晾衣服。",
+			advice.IndoorLaundry:   "建议在室内晾衣服。",
+		},
+		WeeklyIntro:       "%s一周天气",
+		WarningIntro:      "%s气象警报",
+		WarningIssued:     "发布%s！",
+		WarningUpgraded:   "%s升级为%s！",
+		WarningDowngraded: "%s降级为%s",
+		WarningCancelled:  "解除%s",
+	},
+}
+
+var ko = &Lang{
+	Code:      "ko",
+	Sep:       " ",
+	End:       ". ",
+	Weekdays:  [7]string{"일", "월", "화", "수", "목", "금", "토"},
+	DayFormat: "%[2]d월 %[3]d일 %[4]s요일",
+	Phrases: Phrases{
+		Intro:       "%[1]s %[2]s(%[3]s) 날씨: %[4]s",
+		Today:       "오늘",
+		Tomorrow:    "내일",
+		Comma:       ", ",
+		Lowest:      "최저 기온 %s",
+		Highest:     "최고 기온 %s",
+		Higher:      "%[1]s 대비 %[2]d도 높음",
+		Lower:       "%[1]s 대비 %[2]d도 낮음",
+		Same:        "%s 대비 변화 없음",
+		Unknown:     "알 수 없음",
+		POP:         "%[1]s부터 비 올 확률 %[2]d%%",
+		Periods:     [4]string{"새벽", "오전", "오후", "밤"},
+		Wind:        "바람: %s",
+		Wave:        "파도: %s",
+		Tag:         "#bam_weather",
+		Corrected:   "[정정] ",
+		Cancelled:   "[취소] %s 일기예보가 기상청에 의해 취소되었습니다",
+		HighLabel:   "최고:",
+		LowLabel:    "최저:",
+		PageDaily:   "%s 날씨 %s",
+		PageWeekly:  "%s 주간 날씨 %s~",
+		PageWarning: "%s 기상 특보 %s",
+		Advice: map[advice.Item]string{
+			advice.Umbrella:        "우산을 챙기세요.",
+			advice.FoldingUmbrella: "접이식 우산이 있으면 좋겠습니다.",
+			advice.Coat:            "코트를 입으세요.",
+			advice.Layer:           "겹쳐 입기 좋은 옷이 좋겠습니다.",
+			advice.ShortSleeves:    "반팔로 충분합니다.",
+			advice.Laundry:         "빨래를 밖에 널기 좋습니다.",
+			advice.IndoorLaundry:   "빨래는 실내에 너세요.",
+		},
+		WeeklyIntro:       "%s 주간 날씨",
+		WarningIntro:      "%s 기상 특보",
+		WarningIssued:     "%s 발표!",
+		WarningUpgraded:   "%s에서 %s(으)로 격상!",
+		WarningDowngraded: "%s에서 %s(으)로 격하",
+		WarningCancelled:  "%s 해제",
+	},
+}
+
+var langs = func() map[string]*Lang {
+	m := map[string]*Lang{}
+	for i, l := range []*Lang{en, zh, ko} {
+		l.words = map[string]Word{}
+		for _, d := range dictionary {
+			l.words[d.ja] = [...]Word{d.en, d.zh, d.ko}[i]
+			if len(d.ja) > l.longest {
+				l.longest = len(d.ja)
+			}
+		}
+		m[l.Code] = l
+	}
+	return m
+}()
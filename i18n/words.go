@@ -0,0 +1,112 @@
+package i18n
+
+func w(s string) Word      { return Word{Text: s} }
+func before(s string) Word { return Word{Text: s, Before: true} }
+func attach(s string) Word { return Word{Text: s, Attach: true} }
+
+// dictionary is the words of JMA reports in English, Chinese and Korean.
+var dictionary = []struct {
+	ja         string
+	en, zh, ko Word
+}{
+	// weathers
+	{"晴れ", w("sunny"), w("晴"), w("맑음")},
+	{"晴", w("sunny"), w("晴"), w("맑음")},
+	{"くもり", w("cloudy"), w("多云"), w("흐림")},
+	{"曇り", w("cloudy"), w("多云"), w("흐림")},
+	{"雨", w("rain"), w("雨"), w("비")},
+	{"雪", w("snow"), w("雪"), w("눈")},
+	{"雷", w("thunder"), w("雷"), w("천둥")},
+	{"雷雨", w("thunderstorms"), w("雷阵雨"), w("뇌우")},
+	{"霧", w("fog"), w("雾"), w("안개")},
+	{"霧雨", w("drizzle"), w("毛毛雨"), w("이슬비")},
+	{"みぞれ", w("sleet"), w("雨夹雪"), w("진눈깨비")},
+	{"大雨", w("heavy rain"), w("大雨"), w("큰비")},
+	{"大雪", w("heavy snow"), w("大雪"), w("폭설")},
+	{"暴風", w("storm"), w("暴风"), w("폭풍")},
+	{"暴風雪", w("snowstorm"), w("暴风雪"), w("눈보라")},
+	{"風雪", w("wind and snow"), w("风雪"), w("눈바람")},
+	{"止む", w("stopping"), w("停"), w("그침")},
+	{"降る", w(""), w(""), w("")},
+
+	// connectors
+	{"か", w("or"), w("或"), w("또는")},
+	{"時々", attach(", at times"), attach("，有时"), w("때때로")},
+	{"一時", attach(", occasionally"), attach("，短时"), w("한때")},
+	{"後", attach(", later"), attach("，之后"), w("후")},
+	{"のち", attach(", later"), attach("，之后"), w("후")},
+	{"から", before("from"), attach("起"), attach("부터")},
+	{"まで", before("until"), attach("为止"), attach("까지")},
+	{"は", attach(","), attach("，"), attach("에는")},
+	{"で", attach(","), attach("，"), attach(",")},
+	{"、", attach(","), attach("，"), attach(",")},
+	{"を伴う", before("with"), before("伴有"), w("동반")},
+	{"を伴い", before("with"), before("伴有"), w("동반")},
+	{"強く", w("strong"), w("强"), w("강하게")},
+	{"やや強く", w("rather strong"), w("稍强"), w("약간 강하게")},
+	{"激しく", w("heavy"), w("强烈"), w("강하게")},
+	{"非常に", w("very"), w("非常"), w("매우")},
+
+	// times
+	{"未明", w("before dawn"), w("凌晨"), w("새벽")},
+	{"明け方", w("dawn"), w("黎明"), w("새벽녘")},
+	{"朝", w("morning"), w("早上"), w("아침")},
+	{"朝のうち", w("early morning"), w("早晨"), w("아침 한때")},
+	{"朝の内", w("early morning"), w("早晨"), w("아침 한때")},
+	{"昼前", w("late morning"), w("上午"), w("오전")},
+	{"昼頃", w("noon"), w("中午"), w("정오")},
+	{"昼過ぎ", w("early afternoon"), w("午后"), w("오후")},
+	{"午前", w("morning"), w("上午"), w("오전")},
+	{"午後", w("afternoon"), w("下午"), w("오후")},
+	{"日中", w("daytime"), w("白天"), w("낮")},
+	{"夕方", w("evening"), w("傍晚"), w("저녁")},
+	{"夜のはじめ頃", w("early night"), w("入夜"), w("초저녁")},
+	{"夜遅く", w("late night"), w("深夜"), w("늦은 밤")},
+	{"夜", w("at night"), w("夜间"), w("밤")},
+	{"夜から", w("from night"), w("夜间起"), w("밤부터")},
+	{"朝夕", w("morning and evening"), w("早晚"), w("아침저녁")},
+	{"朝晩", w("morning and evening"), w("早晚"), w("아침저녁")},
+	{"昨日", w("yesterday"), w("昨天"), w("어제")},
+	{"今日", w("today"), w("今天"), w("오늘")},
+	{"明日", w("tomorrow"), w("明天"), w("내일")},
+
+	// places
+	{"所により", w("in places"), w("局部地区"), w("곳에 따라")},
+	{"山地", w("mountains"), w("山区"), w("산지")},
+	{"山沿い", w("near the mountains"), w("山区附近"), w("산간")},
+	{"海上", w("at sea"), w("海上"), w("해상")},
+	{"沿岸", w("coast"), w("沿海"), w("해안")},
+	{"では", attach(":"), w(""), attach("에서는")},
+
+	// warnings
+	{"特別警報", w("emergency warning"), w("特别警报"), w("특별경보")},
+	{"警報", w("warning"), w("警报"), w("경보")},
+	{"注意報", w("advisory"), w("注意报"), w("주의보")},
+	{"洪水", w("flood"), w("洪水"), w("홍수")},
+	{"強風", w("gale"), w("强风"), w("강풍")},
+	{"波浪", w("high waves"), w("海浪"), w("풍랑")},
+	{"高潮", w("storm surge"), w("风暴潮"), w("폭풍해일")},
+	{"融雪", w("snowmelt"), w("融雪"), w("융설")},
+	{"濃霧", w("dense fog"), w("浓雾"), w("짙은 안개")},
+	{"乾燥", w("dry air"), w("干燥"), w("건조")},
+	{"なだれ", w("avalanche"), w("雪崩"), w("눈사태")},
+	{"低温", w("low temperature"), w("低温"), w("저온")},
+	{"霜", w("frost"), w("霜冻"), w("서리")},
+	{"着氷", w("icing"), w("积冰"), w("착빙")},
+	{"着雪", w("snow accretion"), w("积雪"), w("착설")},
+
+	// wind and waves
+	{"北", w("north"), w("北"), w("북")},
+	{"北東", w("northeast"), w("东北"), w("북동")},
+	{"東", w("east"), w("东"), w("동")},
+	{"南東", w("southeast"), w("东南"), w("남동")},
+	{"南", w("south"), w("南"), w("남")},
+	{"南西", w("southwest"), w("西南"), w("남서")},
+	{"西", w("west"), w("西"), w("서")},
+	{"北西", w("northwest"), w("西北"), w("북서")},
+	{"の風", w("wind"), attach("风"), attach("풍")},
+	{"風", w("wind"), w("风"), w("바람")},
+	{"波", w("waves"), w("浪"), w("파도")},
+	{"メートル", w("m"), attach("米"), attach("m")},
+	{"うねり", w("swell"), w("涌浪"), w("너울")},
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -9,14 +10,20 @@ import (
 	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/bamchoh/bam-weather/dialect"
 	"github.com/bamchoh/bam-weather/genindex"
 	"github.com/bamchoh/bam-weather/genpng"
-	"github.com/bamchoh/bam-weather/mys3"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/bamchoh/bam-weather/weathercode"
 	"github.com/pkg/errors"
 )
 
+const postingMargin = 10 * time.Second
+
 var (
-	indexURL = "https://s3-ap-northeast-1.amazonaws.com/bam-weather/index.html"
+	indexURL   = "https://s3-ap-northeast-1.amazonaws.com/bam-weather/index.html"
+	weeklyURL  = "https://s3-ap-northeast-1.amazonaws.com/bam-weather/weekly.html"
+	warningURL = "https://s3-ap-northeast-1.amazonaws.com/bam-weather/warning.html"
 )
 
 type Control struct {
@@ -62,36 +69,76 @@ type WeatherForecastPart struct {
 	SubArea   SubArea
 }
 
+type WindForecastPart struct {
+	ID       string `xml:"refID,attr"`
+	Sentence string
+}
+
+type WaveHeightForecastPart struct {
+	ID       string `xml:"refID,attr"`
+	Sentence string
+}
+
 type Temperature struct {
 	Temp        string `xml:",chardata"`
 	Description string `xml:"description,attr"`
 	ID          string `xml:"refID,attr"`
 	Type        string `xml:"type,attr"`
+	Unit        string `xml:"unit,attr"`
+}
+
+// Value returns the temperature, which is missing when it is not forecasted.
+func (t Temperature) Value() (temp.Temp, error) {
+	return temp.Parse(t.Temp, t.Unit)
 }
 
 type TemperaturePart struct {
-	Temperature Temperature `xml:"http://xml.kishou.go.jp/jmaxml1/elementBasis1/ Temperature"`
+	Temperatures []Temperature `xml:"http://xml.kishou.go.jp/jmaxml1/elementBasis1/ Temperature"`
 }
 
 type Property struct {
 	Type             string
-	WeatherForecasts []WeatherForecastPart `xml:"DetailForecast>WeatherForecastPart"`
-	TemperaturePart  TemperaturePart       `xml:"TemperaturePart"`
+	WeatherForecasts []WeatherForecastPart    `xml:"DetailForecast>WeatherForecastPart"`
+	WindForecasts    []WindForecastPart       `xml:"DetailForecast>WindForecastPart"`
+	WaveForecasts    []WaveHeightForecastPart `xml:"DetailForecast>WaveHeightForecastPart"`
+	TemperaturePart  TemperaturePart          `xml:"TemperaturePart"`
+	POPs             []RefValue               `xml:"ProbabilityOfPrecipitationPart>ProbabilityOfPrecipitation"`
+	Weathers         []RefValue               `xml:"WeatherPart>Weather"`
+	WeatherCodes     []RefValue               `xml:"WeatherCodePart>WeatherCode"`
+	WindDirections   []RefValue               `xml:"WindDirectionPart>WindDirection"`
+	WindSpeedLevels  []RefValue               `xml:"WindSpeedPart>WindSpeedLevel"`
+}
+
+type Area struct {
+	Name string
+	Code string
+}
+
+type Station struct {
+	Name string
+	Code string
 }
 
 type Item struct {
-	Kinds []Property `xml:"Kind>Property"`
+	Kinds   []Property `xml:"Kind>Property"`
+	Area    Area
+	Station Station
 }
 
 type TimeDefine struct {
-	ID   string `xml:"timeId,attr"`
-	Name string `xml:"Name"`
+	ID       string `xml:"timeId,attr"`
+	DateTime string `xml:"DateTime"`
+	Name     string `xml:"Name"`
+}
+
+type TimeSeriesInfo struct {
+	TimeDefines []TimeDefine `xml:"TimeDefines>TimeDefine"`
+	Items       []Item       `xml:"Item"`
 }
 
 type MeteorologicalInfo struct {
-	Type        string       `xml:"type,attr"`
-	TimeDefines []TimeDefine `xml:"TimeSeriesInfo>TimeDefines>TimeDefine"`
-	Items       []Item       `xml:"TimeSeriesInfo>Item"`
+	Type            string           `xml:"type,attr"`
+	TimeSeriesInfos []TimeSeriesInfo `xml:"TimeSeriesInfo"`
 }
 
 type Body struct {
@@ -104,34 +151,30 @@ type Report struct {
 	Body    Body
 }
 
+// personas are the voices of the forecast text by name.
+// The built-in ones are overridden by the personas of the config.
+var personas = dialect.Personas()
+
+// ModifySentence turns a sentence of JMA reports into 大阪弁.
 func ModifySentence(s string) string {
-	s = strings.Replace(s, "　", " ", -1)
-	s = strings.Replace(s, "後", "からの", -1)
-	s = strings.Replace(s, "一時", "ちょっとのま", -1)
-	s = strings.Replace(s, "を伴う", "もある", -1)
-	s = strings.Replace(s, "時々", "たま～に", -1)
-	s = strings.Replace(s, "を伴い", "もあるし", -1)
-	s = strings.Replace(s, "非常に", "めっちゃ", -1)
-	s = strings.Replace(s, "激しく", "ぎょーさん", -1)
-	s = strings.Replace(s, "山地", "山のほう", -1)
-	s = strings.Replace(s, "未明", "夜おそぉに", -1)
-	s = strings.Replace(s, "では", "らへんは", -1)
-	s = strings.Replace(s, "所により", "どっかでは", -1)
-	s = strings.Replace(s, "海上", "海のほう", -1)
-	s = strings.Replace(s, "夜遅く", "夜おそぉ", -1)
-	s = strings.Replace(s, "夜のはじめ頃", "会社から退社する頃", -1)
-	s = strings.Replace(s, "晴れ", "\xE2\x98\x80", -1)
-	s = strings.Replace(s, "雨", "\xE2\x98\x94", -1)
-	s = strings.Replace(s, "雪", "\xE2\x9B\x84", -1)
-	s = strings.Replace(s, "くもり", "\xE2\x98\x81", -1)
-	s = strings.Replace(s, "雷", "\xE2\x9A\xA1", -1)
-	return s
+	return personas[dialect.Osaka].Apply(s)
 }
 
+// DayInfo is the forecast of a day.
+// POP holds 6-hour probabilities of precipitation from 00-06 to 18-24,
+// -1 means it is not forecasted.
+// Wind and Wave are the sentences of the wind and wave forecasts,
+// Wave is empty for regions without sea.
+// Hourly is the 3-hourly forecast of the day.
 type DayInfo struct {
-	Weather WeatherForecastPart
-	TempL   string
-	TempH   string
+	Weather     WeatherForecastPart
+	WeatherCode string
+	TempL       temp.Temp
+	TempH       temp.Temp
+	POP         [4]int
+	Wind        string
+	Wave        string
+	Hourly      []HourlyForecast
 }
 
 func (t WeatherInfo) Exists(searchText []string) bool {
@@ -143,85 +186,119 @@ func (t WeatherInfo) Exists(searchText []string) bool {
 	return false
 }
 
-func genWeatherInfo(day *DayInfo, templ, temph string) genpng.WeatherInfo {
-	bases := strings.Split(day.Weather.Base.Weather.Text, " ")
-
-	info := genpng.WeatherInfo{
-		First: bases[0],
-		Low:   strings.Replace(templ, "度", "", -1),
-		High:  strings.Replace(temph, "度", "", -1),
-	}
+// DayTemps is the lowest and highest temperatures of the day
+// and of the day before, which is called PrevName in the text.
+type DayTemps struct {
+	Low      temp.Temp
+	High     temp.Temp
+	PrevLow  temp.Temp
+	PrevHigh temp.Temp
+	PrevName string
+}
 
+// trend returns 1 when t is higher than prev, -1 when lower, otherwise 0.
+func trend(t, prev temp.Temp, u temp.Unit) int {
+	d, ok := t.Delta(prev, u)
 	switch {
-	case len(bases) > 2:
-		info.Second = bases[1]
-		info.Third = bases[2]
-	case len(day.Weather.Becoming) > 0:
-		bec := day.Weather.Becoming[0]
-		mod := bec.TimeModifier
-		switch mod {
-		case "後", "時々":
-			info.Second = mod
-		default:
-			info.Second = "後"
-		}
-		info.Third = day.Weather.Becoming[0].Weather.Text
+	case !ok || d == 0:
+		return 0
+	case d > 0:
+		return 1
 	}
-
-	return info
+	return -1
 }
 
-func generateForecast(wf WeatherForecastPart, tempL, tempH, when string) string {
-	var ws []WeatherInfo
-
-	ws = append(ws, wf.Base)
-	ws = append(ws, wf.Temporary...)
-	ws = append(ws, wf.Becoming...)
+func genWeatherInfo(day *DayInfo, temps DayTemps, opts Options) genpng.WeatherInfo {
+	info := genpng.WeatherInfo{
+		Low:       temps.Low,
+		High:      temps.High,
+		LowTrend:  trend(temps.Low, temps.PrevLow, opts.Unit()),
+		HighTrend: trend(temps.High, temps.PrevHigh, opts.Unit()),
+		Unit:      opts.Unit(),
+		POP:       day.POP,
+	}
 
-	searchText := []string{"時々", "後"}
-	report := ""
-	for _, w := range ws {
-		if w.TimeModifier != "" {
-			if !w.Exists(searchText) {
-				report += ModifySentence("や。")
-			}
-			report += ModifySentence(w.TimeModifier)
-			if !w.Exists(searchText) {
-				report += ModifySentence("は")
+	if opts.WindImage && day.Wind != "" {
+		info.Wind = ModifySentence("風 " + day.Wind)
+		if l, ok, _ := lookupLang(opts.Language); ok {
+			if t, ok := imageText(day.Wind, l); ok {
+				info.Wind = t
 			}
 		}
-		report += ModifySentence(w.Weather.Text)
 	}
-	report += ModifySentence("や。")
 
-	if wf.SubArea.Sentence != "" {
-		report += ModifySentence("なんか")
-		report += ModifySentence(wf.SubArea.Sentence)
-		report += ModifySentence("らしいで")
+	if opts.AdviceImage {
+		for _, item := range dayAdvice(day, temps, opts) {
+			info.Advice = append(info.Advice, string(item))
+		}
+	}
+
+	if t, ok := weathercode.Lookup(day.WeatherCode); ok {
+		info.First = t.Primary
+		info.Second = t.Connector
+		info.Third = t.Secondary
+		return localizeWeatherInfo(info, opts)
+	}
+
+	// the weather code is unknown, so the weather text is used
+	// for up to three weathers
+	bases := strings.Split(day.Weather.Base.Weather.Text, " ")
+	info.First = bases[0]
+	var rest []string
+	if len(bases) > 2 {
+		rest = bases[1:]
+	}
+	for _, w := range day.Weather.Temporary {
+		rest = append(rest, imageConnector(w.TimeModifier, "時々"), w.Weather.Text)
+	}
+	for _, w := range day.Weather.Becoming {
+		rest = append(rest, imageConnector(w.TimeModifier, "のち"), w.Weather.Text)
+	}
+	for len(rest) < 4 {
+		rest = append(rest, "")
 	}
+	info.Second, info.Third, info.Fourth, info.Fifth = rest[0], rest[1], rest[2], rest[3]
 
-	lowest := "いっちゃん低い温度は " + tempL
-	highest := "いっちゃん高い温度は " + tempH + "やで"
-	tag := "#bam_weather"
+	return localizeWeatherInfo(info, opts)
+}
 
-	report = fmt.Sprintf("大阪の%sの天気は基本%s\n%s\n%s\n%s", when, report, lowest, highest, tag)
-	return report
+// imageConnector returns 一時, 時々 or のち in the time modifier mod,
+// or def when it has none of them.
+func imageConnector(mod, def string) string {
+	switch {
+	case strings.Contains(mod, "一時"):
+		return "一時"
+	case strings.Contains(mod, "時々"):
+		return "時々"
+	case strings.Contains(mod, "後"), strings.Contains(mod, "のち"):
+		return "のち"
+	}
+	return def
 }
 
 type WeatherGenerator interface {
-	Init() error
-	Text() string
+	Init(ctx context.Context) error
+	Forecast() Forecast
 	WeatherInfo() genpng.WeatherInfo
+	HourlyChart() genpng.HourlyChart
 	Day() time.Time
+	// Report returns the report which the forecast was made from.
+	Report() ReportRef
 }
 
 type SpecificTime struct {
-	Specify bool `json:"specify"`
-	Day     int  `json:"day"`
-	Hour    int  `json:"hour"`
+	Specify    bool   `json:"specify"`
+	Day        int    `json:"day"`
+	Hour       *int   `json:"hour"`
+	Source     string `json:"source"`
+	FixtureDir string `json:"fixture_dir"`
+	Region     string `json:"region"`
+	Config     string `json:"config"`
+	Mode       string `json:"mode"`
+	Date       string `json:"date"`
 }
 
-func run(event SpecificTime) error {
+func run(ctx context.Context, event SpecificTime) error {
 	var err error
 	logFile := os.Stdout
 	if err != nil {
@@ -239,74 +316,361 @@ func run(event SpecificTime) error {
 			log.Println(err)
 			return err
 		}
+		hour := 0
+		if event.Hour != nil {
+			hour = *event.Hour
+		}
 		tt = time.Date(
 			tt.Year(),
 			tt.Month(),
 			event.Day,
-			event.Hour,
+			hour,
 			0,
 			0,
 			0,
 			loc)
 	}
 
-	var gen WeatherGenerator
+	// leave time to upload and post after fetching reports
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-postingMargin))
+		defer cancel()
+	}
+
+	cfg, err := loadConfig(event)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	store := newStore(cfg)
+
+	err = loadForecastTemplates(cfg, store)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	if event.Mode == "replay" {
+		return runReplay(ctx, event, cfg, store)
+	}
+
+	src, err := newForecastSource(event, cfg, store)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	switch event.Mode {
+	case "weekly":
+		return runWeekly(ctx, cfg, src, store, tt)
+	case "warnings":
+		return runWarnings(ctx, cfg, src, store, tt)
+	case "corrections":
+		return runCorrections(ctx, cfg, src, store, tt)
+	}
+
+	gen := newDailyGenerator(cfg, src, tt)
+	err = gen.Init(ctx)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	daily, err := publishDaily(store, "", gen, cfg, tt)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	hourly := publishHourly(store, "", gen, cfg)
+
+	f := gen.Forecast()
+	postErr := postChannels(cfg, func(c Channel) []string {
+		return c.composeForecast(f, "")
+	}, daily, hourly)
+
+	// the record is kept even when a channel failed,
+	// so the others are not posted twice
+	err = savePostRecord(store, &PostRecord{
+		BaseTime: tt,
+		Report:   gen.Report(),
+		Text:     recordChannel(cfg).forecastText(f),
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	return postErr
+}
+
+func newDailyGenerator(cfg *Config, src ForecastSource, tt time.Time) WeatherGenerator {
 	if tt.Hour() >= 18 {
 		log.Println("Tomorrow")
-		gen = &TomorrowWeatherGenerator{
+		return &TomorrowWeatherGenerator{
 			BaseTime: tt,
+			Source:   src,
+			Region:   cfg.Region,
+			Options:  cfg.Options,
 		}
+	}
+	log.Println("Today")
+	return &TodayWeatherGenerator{
+		BaseTime: tt,
+		Source:   src,
+		Region:   cfg.Region,
+		Options:  cfg.Options,
+	}
+}
+
+// publishDaily puts weather.png, index.html and forecast.ssml of gen to
+// the store under prefix and returns the image.
+func publishDaily(store Store, prefix string, gen WeatherGenerator, cfg *Config, tt time.Time) (media, error) {
+	f := gen.Forecast()
+	info := gen.WeatherInfo()
+	info.Look = imageLook(cfg.Options, outputDaily, f.Date, f.Tomorrow)
+	var buffer *bytes.Buffer
+	buffer = bytes.NewBuffer(make([]byte, 0))
+	img := media{alt: info.AltText()}
+	err := genpng.Generate(info, buffer)
+	if err != nil {
+		// the forecast is still posted without the image
+		log.Println(err)
 	} else {
-		log.Println("Today")
-		gen = &TodayWeatherGenerator{
-			BaseTime: tt,
+		img.data = buffer.Bytes()
+		err = store.Put(prefix+"weather.png", "binary/octet-stream", img.data)
+		if err != nil {
+			return media{}, err
 		}
 	}
 
-	err = gen.Init()
+	buffer = bytes.NewBuffer(make([]byte, 0))
+	err = genindex.Generate(buffer, cfg.Options.Language, cfg.Region.NameIn(cfg.Options.Language), gen.Day(), img.alt, tt.Unix())
+	if err != nil {
+		return media{}, err
+	}
+
+	err = store.Put(prefix+"index.html", "text/html", buffer.Bytes())
 	if err != nil {
+		return media{}, err
+	}
+
+	err = store.Put(prefix+"forecast.ssml", "application/ssml+xml", []byte(forecastSSML(f)))
+	if err != nil {
+		// the speech is optional, the forecast is still posted
+		log.Println(err)
+	}
+	return img, nil
+}
+
+// publishHourly puts hourly.png of gen to the store under prefix and returns it.
+// It returns no data when the report has no 地域時系列予報 for the day,
+// or when the chart failed, which is logged and posted without.
+func publishHourly(store Store, prefix string, gen WeatherGenerator, cfg *Config) media {
+	chart := gen.HourlyChart()
+	if len(chart.Slots) == 0 {
+		return media{}
+	}
+	f := gen.Forecast()
+	chart.Look = imageLook(cfg.Options, outputHourly, f.Date, f.Tomorrow)
+
+	buffer := bytes.NewBuffer(make([]byte, 0))
+	err := genpng.GenerateHourly(chart, buffer)
+	if err != nil {
+		log.Println(err)
+		return media{}
+	}
+
+	err = store.Put(prefix+"hourly.png", "binary/octet-stream", buffer.Bytes())
+	if err != nil {
+		log.Println(err)
+		return media{}
+	}
+	return media{data: buffer.Bytes(), alt: hourlyAlt(chart, cfg.Options)}
+}
+
+// runReplay re-generates the daily outputs of event.Date from archived reports.
+// event.Hour is required, as the hour of the run selects the report.
+// The outputs are put under replay/<date>/ of the store and never posted.
+func runReplay(ctx context.Context, event SpecificTime, cfg *Config, store Store) error {
+	log.Println("Replay")
+	date, err := time.ParseInLocation("2006-01-02", event.Date, time.Local)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse replay date")
 		log.Println(err)
 		return err
 	}
-	info := gen.WeatherInfo()
+	if event.Hour == nil || *event.Hour < 0 || *event.Hour > 23 {
+		err = errors.New("replay needs hour (0-23) of the run")
+		log.Println(err)
+		return err
+	}
+	tt := date.Add(time.Duration(*event.Hour) * time.Hour)
+
+	src := &ArchiveSource{Store: store, Office: cfg.Region.PublishingOffice}
+	gen := newDailyGenerator(cfg, src, tt)
+	err = gen.Init(ctx)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	prefix := "replay/" + date.Format("20060102") + "/"
+	_, err = publishDaily(store, prefix, gen, cfg, tt)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	publishHourly(store, prefix, gen, cfg)
+
+	text := recordChannel(cfg).forecastText(gen.Forecast())
+	log.Println("Text:", text)
+	return store.Put(prefix+"text.txt", "text/plain", []byte(text))
+}
+
+func runWeekly(ctx context.Context, cfg *Config, src ForecastSource, store Store, tt time.Time) error {
+	log.Println("Weekly")
+	gen := &WeeklyWeatherGenerator{
+		BaseTime: tt,
+		Source:   src,
+		Region:   cfg.Region,
+		Options:  cfg.Options,
+	}
 
-	bucket := "bam-weather"
-	region := "ap-northeast-1"
+	err := gen.Init(ctx)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
 
 	var buffer *bytes.Buffer
 	buffer = bytes.NewBuffer(make([]byte, 0))
-	err = genpng.Generate(info, buffer)
+	look := imageLook(cfg.Options, outputWeekly, tt, tt.Hour() >= 18)
+	err = genpng.GenerateWeekly(gen.WeeklyDays(), look, buffer)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	err = mys3.Upload(bucket, region, "weather.png", "binary/octet-stream", buffer)
+	err = store.Put("weekly.png", "binary/octet-stream", buffer.Bytes())
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
 	buffer = bytes.NewBuffer(make([]byte, 0))
-	err = genindex.Generate(buffer, gen.Day(), tt.Unix())
+	err = genindex.GenerateWeekly(buffer, cfg.Options.Language, cfg.Region.NameIn(cfg.Options.Language), gen.Day(), tt.Unix())
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	err = mys3.Upload(bucket, region, "index.html", "text/html", buffer)
+	err = store.Put("weekly.html", "text/html", buffer.Bytes())
 	if err != nil {
 		log.Println(err)
 		return err
 	}
 
-	text := gen.Text()
-	log.Println("Text:", text)
-	tweet(text)
+	link := fmt.Sprintf("%v?%d", weeklyURL, tt.Unix())
+	return postChannels(cfg, func(c Channel) []string {
+		return c.compose(c.weeklyText(cfg.Region, gen.Days(), cfg.Options.Unit()) + "\n" + link)
+	})
+}
 
-	return nil
+func runWarnings(ctx context.Context, cfg *Config, src ForecastSource, store Store, tt time.Time) error {
+	log.Println("Warnings")
+
+	ref, err := findReport(ctx, src, warningTitle, tt.Add(-24*time.Hour), tt)
+	if err != nil {
+		if errors.Cause(err) == errLinkNotFound {
+			log.Println("no warning report")
+			return nil
+		}
+		log.Println(err)
+		return err
+	}
+
+	var v WarningReport
+	if err := src.FetchReport(ctx, ref.Link, &v); err != nil {
+		log.Println(err)
+		return err
+	}
+
+	prev, err := loadWarningState(store)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if prev.ReportDateTime == v.Head.ReportDateTime {
+		log.Println("warning report was already seen:", v.Head.ReportDateTime)
+		return nil
+	}
+
+	kinds, err := warningsInEffect(&v, cfg.Region.OfficeCode)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	var postErr error
+	notices := diffWarnings(prev.Kinds, kinds)
+	if len(notices) > 0 {
+		var buffer *bytes.Buffer
+		buffer = bytes.NewBuffer(make([]byte, 0))
+		err = genpng.GenerateBanner(genWarningBanner(notices), buffer)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = store.Put("warning.png", "binary/octet-stream", buffer.Bytes())
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		buffer = bytes.NewBuffer(make([]byte, 0))
+		err = genindex.GenerateWarning(buffer, cfg.Options.Language, cfg.Region.NameIn(cfg.Options.Language), tt, tt.Unix())
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		err = store.Put("warning.html", "text/html", buffer.Bytes())
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+
+		link := fmt.Sprintf("%v?%d", warningURL, tt.Unix())
+		postErr = postChannels(cfg, func(c Channel) []string {
+			return c.compose(c.warningText(cfg.Region, notices) + "\n" + link)
+		})
+	}
+
+	// the state is kept even when a channel failed,
+	// so the others are not posted twice
+	err = saveWarningState(store, &WarningState{
+		ReportDateTime: v.Head.ReportDateTime,
+		Kinds:          kinds,
+	})
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return postErr
 }
 
 func main() {
+	// the font and icons are loaded while the reports are fetched,
+	// and kept for warm starts
+	go func() {
+		if err := genpng.Preload(); err != nil {
+			log.Println(err)
+		}
+	}()
 	lambda.Start(run)
 }
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/bamchoh/bam-weather/genpng"
+)
+
+func TestGenWeatherInfo(t *testing.T) {
+	tests := []struct {
+		sentence, code string
+		want           [5]string
+	}{
+		{"くもり　時々　雨　で　雷を伴う", "240", [5]string{"くもり", "時々", "雷"}},
+		{"くもり　時々　雨　で　雷を伴う", "", [5]string{"くもり", "時々", "雨　で　雷を伴う"}},
+		{"晴れ　夕方　から　くもり", "101", [5]string{"晴れ", "時々", "くもり"}},
+		{"晴れ　夕方　から　くもり", "", [5]string{"晴れ", "のち", "くもり"}},
+		{"雨　後　雪", "315", [5]string{"雨", "のち", "雪"}},
+		{"晴れ", "999", [5]string{"晴れ"}},
+	}
+	for _, tt := range tests {
+		day := &DayInfo{Weather: parseWeatherSentence(tt.sentence), WeatherCode: tt.code}
+		info := genWeatherInfo(day, DayTemps{}, Options{})
+		got := [5]string{info.First, info.Second, info.Third, info.Fourth, info.Fifth}
+		if got != tt.want {
+			t.Errorf("genWeatherInfo(%q, %q) = %q, want %q", tt.sentence, tt.code, got, tt.want)
+		}
+		if err := genpng.Generate(info, ioutil.Discard); err != nil {
+			t.Errorf("genWeatherInfo(%q, %q) cannot be drawn: %v", tt.sentence, tt.code, err)
+		}
+	}
+}
@@ -1,26 +1,37 @@
 package mys3
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"log"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 )
 
-func Upload(bucket, region, key, contentType string, buffer io.Reader) error {
+// ErrNotFound is returned by Download when the key does not exist.
+var ErrNotFound = errors.New("mys3: key was not found")
+
+// Upload puts buffer to key, readable by anyone when public is set.
+func Upload(bucket, region, key, contentType string, public bool, buffer io.Reader) error {
 	sess := session.Must(session.NewSession(&aws.Config{
 		Region: &region,
 	}))
 
 	uploader := s3manager.NewUploader(sess)
 
+	acl := s3.ObjectCannedACLPrivate
+	if public {
+		acl = s3.ObjectCannedACLPublicRead
+	}
 	result, err := uploader.Upload(&s3manager.UploadInput{
 		Bucket:      aws.String(bucket),
 		Key:         aws.String(key),
-		ACL:         aws.String("public-read"),
+		ACL:         aws.String(acl),
 		ContentType: aws.String(contentType),
 		Body:        buffer,
 	})
@@ -30,3 +41,48 @@ func Upload(bucket, region, key, contentType string, buffer io.Reader) error {
 	log.Printf("file uploaded to, %s\n", aws.StringValue(&result.Location))
 	return nil
 }
+
+func Download(bucket, region, key string) ([]byte, error) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region: &region,
+	}))
+
+	downloader := s3manager.NewDownloader(sess)
+
+	buf := aws.NewWriteAtBuffer(make([]byte, 0))
+	_, err := downloader.Download(buf, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to download file, %v", err)
+	}
+	log.Printf("file downloaded from, %s/%s\n", bucket, key)
+	return buf.Bytes(), nil
+}
+
+func List(bucket, region, prefix string) ([]string, error) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region: &region,
+	}))
+
+	svc := s3.New(sess)
+
+	var keys []string
+	err := svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			keys = append(keys, aws.StringValue(obj.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files, %v", err)
+	}
+	return keys, nil
+}
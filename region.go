@@ -0,0 +1,105 @@
+package main
+
+import "fmt"
+
+// Region describes which forecast the bot reads and how it is named.
+type Region struct {
+	// Name is used in posted texts and index.html (e.g. 大阪).
+	Name string `json:"name"`
+	// PublishingOffice is the author of the reports in the JMA feed.
+	PublishingOffice string `json:"publishing_office"`
+	// OfficeCode is the code of the 府県予報区 (e.g. 270000).
+	OfficeCode string `json:"office_code"`
+	// AreaCode is the code of the 一次細分区域 used for weather forecasts.
+	AreaCode string `json:"area_code"`
+	// Station is the name of the station used for point forecasts.
+	Station string `json:"station"`
+	// Names are the names in other languages by language code (e.g. en: Osaka).
+	Names map[string]string `json:"names"`
+}
+
+// NameIn returns the name in the language, or Name when it is not given.
+func (r Region) NameIn(lang string) string {
+	if name, ok := r.Names[lang]; ok {
+		return name
+	}
+	return r.Name
+}
+
+// clone returns r with its own Names, so the presets are not changed
+// through the returned region.
+func (r Region) clone() Region {
+	names := make(map[string]string, len(r.Names))
+	for lang, name := range r.Names {
+		names[lang] = name
+	}
+	r.Names = names
+	return r
+}
+
+var regions = map[string]Region{
+	"osaka": {
+		Name:             "大阪",
+		PublishingOffice: "大阪管区気象台",
+		OfficeCode:       "270000",
+		AreaCode:         "270000",
+		Station:          "大阪",
+		Names:            map[string]string{"en": "Osaka", "zh": "大阪", "ko": "오사카"},
+	},
+	"kyoto": {
+		Name:             "京都",
+		PublishingOffice: "京都地方気象台",
+		OfficeCode:       "260000",
+		AreaCode:         "260010",
+		Station:          "京都",
+		Names:            map[string]string{"en": "Kyoto", "zh": "京都", "ko": "교토"},
+	},
+	"hyogo": {
+		Name:             "神戸",
+		PublishingOffice: "神戸地方気象台",
+		OfficeCode:       "280000",
+		AreaCode:         "280010",
+		Station:          "神戸",
+		Names:            map[string]string{"en": "Kobe", "zh": "神户", "ko": "고베"},
+	},
+	"tokyo": {
+		Name:             "東京",
+		PublishingOffice: "気象庁",
+		OfficeCode:       "130000",
+		AreaCode:         "130010",
+		Station:          "東京",
+		Names:            map[string]string{"en": "Tokyo", "zh": "东京", "ko": "도쿄"},
+	},
+	"aichi": {
+		Name:             "名古屋",
+		PublishingOffice: "名古屋地方気象台",
+		OfficeCode:       "230000",
+		AreaCode:         "230010",
+		Station:          "名古屋",
+		Names:            map[string]string{"en": "Nagoya", "zh": "名古屋", "ko": "나고야"},
+	},
+	"fukuoka": {
+		Name:             "福岡",
+		PublishingOffice: "福岡管区気象台",
+		OfficeCode:       "400000",
+		AreaCode:         "400010",
+		Station:          "福岡",
+		Names:            map[string]string{"en": "Fukuoka", "zh": "福冈", "ko": "후쿠오카"},
+	},
+	"sapporo": {
+		Name:             "札幌",
+		PublishingOffice: "札幌管区気象台",
+		OfficeCode:       "016000",
+		AreaCode:         "016010",
+		Station:          "札幌",
+		Names:            map[string]string{"en": "Sapporo", "zh": "札幌", "ko": "삿포로"},
+	},
+}
+
+func lookupRegion(name string) (Region, error) {
+	r, ok := regions[name]
+	if !ok {
+		return Region{}, fmt.Errorf("region (%v) is not supported", name)
+	}
+	return r.clone(), nil
+}
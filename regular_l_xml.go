@@ -1,15 +1,18 @@
 package main
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
-	"log"
-	"net/http"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
+const (
+	regularLURL = "http://www.data.jma.go.jp/developer/xml/feed/regular_l.xml"
+	extraLURL   = "http://www.data.jma.go.jp/developer/xml/feed/extra_l.xml"
+)
+
 type RegularLXml struct {
 	Entries []Entry `xml:"entry"`
 }
@@ -25,33 +28,49 @@ type Link struct {
 	URL string `xml:"href,attr"`
 }
 
-func getXMLLink2(sday, eday time.Time) (string, error) {
-	fetchURL := "http://www.data.jma.go.jp/developer/xml/feed/regular_l.xml"
+// RegularLSource reads reports through the JMA regular_l.xml feed,
+// or the extra_l.xml feed for warnings.
+// Office is the publishing office whose reports are picked up.
+type RegularLSource struct {
+	Office  string
+	Fetcher *Fetcher
+}
+
+func (s *RegularLSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	return getXMLLink2(ctx, s.Fetcher, title, s.Office, sday, eday)
+}
 
-	log.Println("Fetch URL:", fetchURL)
+func (s *RegularLSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	return s.Fetcher.FetchReport(ctx, link, v)
+}
 
-	resp, err := http.Get(fetchURL)
-	if err != nil {
-		return "", fmt.Errorf("getXMLLink2:fetch error:%v", err)
+func feedURL(title string) string {
+	if title == warningTitle {
+		return extraLURL
 	}
-	defer resp.Body.Close()
+	return regularLURL
+}
 
-	dec := xml.NewDecoder(resp.Body)
+func getXMLLink2(ctx context.Context, f *Fetcher, title, office string, sday, eday time.Time) ([]string, error) {
 	var r RegularLXml
-	if err := dec.Decode(&r); err != nil {
-		return "", fmt.Errorf("getXMLLink2:decode error:%v", err)
+	if err := f.FetchFeed(ctx, feedURL(title), &r); err != nil {
+		return nil, fmt.Errorf("getXMLLink2:%v", err)
 	}
 
+	var links []string
 	for _, entry := range r.Entries {
-		if entry.Title == "府県天気予報" && entry.Author == "大阪管区気象台" {
+		if entry.Title == title && entry.Author == office {
 			tt, err := time.Parse("2006-01-02T15:04:05Z", entry.Updated)
 			if err != nil {
-				return "", fmt.Errorf("getXMLLink2:parse error:%v", err)
+				return nil, fmt.Errorf("getXMLLink2:parse error:%v", err)
 			}
 			if tt.Before(eday) && tt.After(sday) {
-				return entry.Link.URL, nil
+				links = append(links, entry.Link.URL)
 			}
 		}
 	}
-	return "", errors.New("getXMLLink2:link was not found")
+	if len(links) == 0 {
+		return nil, errors.Wrap(errLinkNotFound, "getXMLLink2")
+	}
+	return links, nil
 }
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const infoTypeCancelled = "取消"
+
+// ReportRef identifies a published report.
+// A report is corrected or cancelled by another report which has
+// the same EventID and ReportDateTime and the later DateTime.
+type ReportRef struct {
+	Link           string `json:"link"`
+	EventID        string `json:"event_id"`
+	ReportDateTime string `json:"report_datetime"`
+	InfoType       string `json:"info_type"`
+	Serial         string `json:"serial"`
+	DateTime       string `json:"datetime"`
+}
+
+// reportHeader is the part of a report used to select it.
+type reportHeader struct {
+	Control Control
+	Head    Head
+}
+
+func newReportRef(link string, control Control, head Head) ReportRef {
+	return ReportRef{
+		Link:           link,
+		EventID:        head.EventID,
+		ReportDateTime: head.ReportDateTime,
+		InfoType:       head.InfoType,
+		Serial:         head.Serial,
+		DateTime:       control.DateTime,
+	}
+}
+
+// sameReport reports whether r and o are versions of the same report.
+func (r ReportRef) sameReport(o ReportRef) bool {
+	return r.EventID == o.EventID && r.ReportDateTime == o.ReportDateTime
+}
+
+// newerThan reports whether r was published after o.
+// Reports are ordered by ReportDateTime, Serial and then DateTime.
+func (r ReportRef) newerThan(o ReportRef) bool {
+	if c := compareTime(r.ReportDateTime, o.ReportDateTime); c != 0 {
+		return c > 0
+	}
+	if rn, on := serialNumber(r.Serial), serialNumber(o.Serial); rn != on {
+		return rn > on
+	}
+	return compareTime(r.DateTime, o.DateTime) > 0
+}
+
+func compareTime(a, b string) int {
+	ta, erra := time.Parse(time.RFC3339, a)
+	tb, errb := time.Parse(time.RFC3339, b)
+	switch {
+	case erra != nil || errb != nil:
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case ta.Before(tb):
+		return -1
+	case ta.After(tb):
+		return 1
+	}
+	return 0
+}
+
+// serialNumber returns 0 for an empty or broken Serial.
+func serialNumber(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// findReportRefs returns the refs of the reports which src found.
+// Reports in training or test (Control.Status other than 通常) are dropped.
+func findReportRefs(ctx context.Context, src ForecastSource, title string, sday, eday time.Time) ([]ReportRef, error) {
+	links, err := src.FindReports(ctx, title, sday, eday)
+	if err != nil {
+		return nil, err
+	}
+
+	var refs []ReportRef
+	seen := map[string]bool{}
+	for _, link := range links {
+		if seen[link] {
+			continue
+		}
+		seen[link] = true
+
+		var v reportHeader
+		if err := src.FetchReport(ctx, link, &v); err != nil {
+			return nil, errors.Wrap(err, "failed to fetch report header")
+		}
+		if v.Control.Status != "通常" {
+			log.Printf("skip report %s (status: %s)\n", link, v.Control.Status)
+			continue
+		}
+		refs = append(refs, newReportRef(link, v.Control, v.Head))
+	}
+	return refs, nil
+}
+
+// latestVersions returns the latest version of each report in refs
+// from the newest report, both ordered by newerThan.
+// Cancelled reports are left out.
+func latestVersions(refs []ReportRef) []ReportRef {
+	var latest []ReportRef
+	for _, ref := range refs {
+		found := false
+		for i, l := range latest {
+			if l.sameReport(ref) {
+				if ref.newerThan(l) {
+					latest[i] = ref
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			latest = append(latest, ref)
+		}
+	}
+
+	var valid []ReportRef
+	for _, ref := range latest {
+		if ref.InfoType == infoTypeCancelled {
+			log.Printf("skip cancelled report %s (%s %s)\n", ref.Link, ref.EventID, ref.ReportDateTime)
+			continue
+		}
+		valid = append(valid, ref)
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].newerThan(valid[j])
+	})
+	return valid
+}
+
+// findReport returns the latest valid report titled title
+// which was published between sday and eday.
+func findReport(ctx context.Context, src ForecastSource, title string, sday, eday time.Time) (ReportRef, error) {
+	refs, err := findReportRefs(ctx, src, title, sday, eday)
+	if err != nil {
+		return ReportRef{}, err
+	}
+
+	valid := latestVersions(refs)
+	if len(valid) == 0 {
+		return ReportRef{}, errors.Wrap(errLinkNotFound, "findReport")
+	}
+	return valid[0], nil
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// testSource serves report headers by link. FindReports returns the links
+// whose DateTime is in the window, in the order of links.
+type testSource struct {
+	links   []string
+	reports map[string]reportHeader
+}
+
+func (s *testSource) add(link, status, eventID, reported, infoType, serial, published string) *testSource {
+	if s.reports == nil {
+		s.reports = map[string]reportHeader{}
+	}
+	s.links = append(s.links, link)
+	s.reports[link] = reportHeader{
+		Control: Control{Status: status, DateTime: published},
+		Head:    Head{EventID: eventID, ReportDateTime: reported, InfoType: infoType, Serial: serial},
+	}
+	return s
+}
+
+func (s *testSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	var links []string
+	for _, link := range s.links {
+		tt, _ := time.Parse(time.RFC3339, s.reports[link].Control.DateTime)
+		if tt.After(sday) && tt.Before(eday) {
+			links = append(links, link)
+		}
+	}
+	if len(links) == 0 {
+		return nil, errLinkNotFound
+	}
+	return links, nil
+}
+
+func (s *testSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	r, ok := s.reports[link]
+	if !ok {
+		return fmt.Errorf("no report %v", link)
+	}
+	*v.(*reportHeader) = r
+	return nil
+}
+
+func testRef(link, eventID, reported, infoType, serial, published string) ReportRef {
+	return ReportRef{Link: link, EventID: eventID, ReportDateTime: reported, InfoType: infoType, Serial: serial, DateTime: published}
+}
+
+func TestLatestVersions(t *testing.T) {
+	morning := testRef("a", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00")
+	corrected := testRef("b", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T05:30:00+09:00")
+	// the same report time, a later serial published at the same time
+	reissued := testRef("c", "270000", "2020-09-10T05:00:00+09:00", "訂正", "3", "2020-09-10T05:30:00+09:00")
+	cancelled := testRef("d", "270000", "2020-09-10T05:00:00+09:00", infoTypeCancelled, "4", "2020-09-10T06:00:00+09:00")
+	noon := testRef("e", "270000", "2020-09-10T11:00:00+09:00", "発表", "", "2020-09-10T10:40:00+09:00")
+	other := testRef("f", "280000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00")
+
+	tests := []struct {
+		name string
+		refs []ReportRef
+		want []ReportRef
+	}{
+		{"single", []ReportRef{morning}, []ReportRef{morning}},
+		{"newest first", []ReportRef{morning, noon}, []ReportRef{noon, morning}},
+		{"corrected", []ReportRef{morning, corrected}, []ReportRef{corrected}},
+		{"corrected in any order", []ReportRef{corrected, morning}, []ReportRef{corrected}},
+		{"serial at the same time", []ReportRef{morning, corrected, reissued}, []ReportRef{reissued}},
+		{"serial in any order", []ReportRef{reissued, corrected, morning}, []ReportRef{reissued}},
+		{"cancelled", []ReportRef{morning, corrected, cancelled}, nil},
+		{"cancelled but another report", []ReportRef{morning, cancelled, noon}, []ReportRef{noon}},
+		{"other event", []ReportRef{morning, other}, []ReportRef{morning, other}},
+	}
+	for _, tt := range tests {
+		if got := latestVersions(tt.refs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: latestVersions() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFindReport(t *testing.T) {
+	src := (&testSource{}).
+		add("a", "通常", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00").
+		add("b", "通常", "270000", "2020-09-10T11:00:00+09:00", "発表", "", "2020-09-10T10:40:00+09:00").
+		add("c", "訓練", "270000", "2020-09-10T11:00:00+09:00", "訂正", "9", "2020-09-10T11:10:00+09:00").
+		add("a", "通常", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00")
+	ctx := context.Background()
+	day := time.Date(2020, 9, 10, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		sday, eday time.Time
+		want       string
+		err        error
+	}{
+		{day, day.Add(24 * time.Hour), "b", nil},
+		{day, day.Add(6 * time.Hour), "a", nil},
+		{day.Add(12 * time.Hour), day.Add(24 * time.Hour), "", errLinkNotFound},
+	}
+	for _, tt := range tests {
+		ref, err := findReport(ctx, src, "府県天気予報", tt.sday, tt.eday)
+		if errors.Cause(err) != tt.err {
+			t.Errorf("findReport(%v, %v) error = %v, want %v", tt.sday, tt.eday, err, tt.err)
+			continue
+		}
+		if ref.Link != tt.want {
+			t.Errorf("findReport(%v, %v) = %v, want %v", tt.sday, tt.eday, ref.Link, tt.want)
+		}
+	}
+}
+
+func TestFindCorrection(t *testing.T) {
+	posted := testRef("a", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00")
+	base := func() *testSource {
+		return (&testSource{}).
+			add("a", "通常", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00").
+			add("e", "通常", "270000", "2020-09-10T11:00:00+09:00", "発表", "", "2020-09-10T10:40:00+09:00")
+	}
+	tests := []struct {
+		name string
+		src  *testSource
+		want string
+		ok   bool
+	}{
+		{"not corrected", base(), "a", false},
+		{"corrected", base().
+			add("b", "通常", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T05:30:00+09:00"), "b", true},
+		{"later serial", base().
+			add("c", "通常", "270000", "2020-09-10T05:00:00+09:00", "訂正", "3", "2020-09-10T05:30:00+09:00").
+			add("b", "通常", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T05:30:00+09:00"), "c", true},
+		{"cancelled", base().
+			add("b", "通常", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T05:30:00+09:00").
+			add("d", "通常", "270000", "2020-09-10T05:00:00+09:00", infoTypeCancelled, "3", "2020-09-10T06:00:00+09:00"), "d", true},
+		{"training", base().
+			add("b", "訓練", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T05:30:00+09:00"), "a", false},
+	}
+	tt0, _ := time.Parse(time.RFC3339, "2020-09-10T12:00:00+09:00")
+	for _, tt := range tests {
+		ref, ok, err := findCorrection(context.Background(), tt.src, &PostRecord{Report: posted}, tt0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.Link != tt.want || ok != tt.ok {
+			t.Errorf("%s: findCorrection() = %v, %v, want %v, %v", tt.name, ref.Link, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPinnedSource(t *testing.T) {
+	// the correction b was published after the window of the daily run
+	src := &pinnedSource{
+		ForecastSource: (&testSource{}).
+			add("a", "通常", "270000", "2020-09-10T05:00:00+09:00", "発表", "", "2020-09-10T04:40:00+09:00"),
+		Title: "府県天気予報",
+		Ref:   testRef("b", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T13:00:00+09:00"),
+	}
+	src.ForecastSource.(*testSource).
+		add("b", "通常", "270000", "2020-09-10T05:00:00+09:00", "訂正", "2", "2020-09-10T13:00:00+09:00")
+	ctx := context.Background()
+	day := time.Date(2020, 9, 10, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		title      string
+		sday, eday time.Time
+		want       []string
+		err        error
+	}{
+		{"府県天気予報", day, day.Add(6 * time.Hour), []string{"a", "b"}, nil},
+		{"府県週間天気予報", day, day.Add(6 * time.Hour), []string{"a"}, nil},
+		{"府県天気予報", day.Add(6 * time.Hour), day.Add(12 * time.Hour), nil, errLinkNotFound},
+		{"府県天気予報", day.Add(3 * time.Hour), day.Add(4 * time.Hour), nil, errLinkNotFound},
+		{"府県天気予報", day.Add(4*time.Hour + 50*time.Minute), day.Add(6 * time.Hour), []string{"b"}, nil},
+	}
+	for _, tt := range tests {
+		links, err := src.FindReports(ctx, tt.title, tt.sday, tt.eday)
+		if errors.Cause(err) != tt.err || !reflect.DeepEqual(links, tt.want) {
+			t.Errorf("FindReports(%v, %v, %v) = %v, %v, want %v, %v", tt.title, tt.sday, tt.eday, links, err, tt.want, tt.err)
+		}
+	}
+
+	ref, err := findReport(ctx, src, "府県天気予報", day, day.Add(6*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref.Link != "b" {
+		t.Errorf("findReport() = %v, want the pinned correction b", ref.Link)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// errLinkNotFound is the cause of FindReports and findReport errors
+// when no report was published in the window.
+var errLinkNotFound = errors.New("link was not found")
+
+// ForecastSource finds and fetches JMA reports.
+type ForecastSource interface {
+	// FindReports returns links to the reports titled title
+	// which were published between sday and eday.
+	// Use findReport to pick the one to read.
+	FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error)
+	// FetchReport fetches the report at link and decodes it into v.
+	FetchReport(ctx context.Context, link string, v interface{}) error
+}
+
+func newForecastSource(event SpecificTime, cfg *Config, store Store) (ForecastSource, error) {
+	fetcher := &Fetcher{Retries: defaultFetchRetries}
+	if cfg.Archive {
+		fetcher.Archive = store
+	}
+
+	source := event.Source
+	if source == "" {
+		source = cfg.Source
+	}
+
+	switch source {
+	case "", "regular_l":
+		src := &RegularLSource{Office: cfg.Region.PublishingOffice, Fetcher: fetcher}
+		if !cfg.Failover {
+			return src, nil
+		}
+		return &failoverSource{
+			Primary:   src,
+			Secondary: &BosaiSource{OfficeCode: cfg.Region.OfficeCode, Fetcher: fetcher},
+		}, nil
+	case "jma_json":
+		return &BosaiSource{OfficeCode: cfg.Region.OfficeCode, Fetcher: fetcher}, nil
+	case "jmardb":
+		return &JmardbSource{AreaCode: cfg.Region.OfficeCode, Fetcher: fetcher}, nil
+	case "dir":
+		return &DirSource{Dir: event.FixtureDir, Office: cfg.Region.PublishingOffice}, nil
+	default:
+		return nil, fmt.Errorf("source (%v) is not supported", source)
+	}
+}
+
+// failoverSource finds reports in Secondary when Primary finds nothing.
+type failoverSource struct {
+	Primary   ForecastSource
+	Secondary ForecastSource
+
+	secondaryLinks map[string]bool
+}
+
+func (s *failoverSource) FindReports(ctx context.Context, title string, sday, eday time.Time) ([]string, error) {
+	links, err := s.Primary.FindReports(ctx, title, sday, eday)
+	if err == nil || errors.Cause(err) != errLinkNotFound {
+		return links, err
+	}
+
+	log.Printf("%v, failing over to %T\n", err, s.Secondary)
+	links, err = s.Secondary.FindReports(ctx, title, sday, eday)
+	if err != nil {
+		return nil, err
+	}
+	if s.secondaryLinks == nil {
+		s.secondaryLinks = map[string]bool{}
+	}
+	for _, link := range links {
+		s.secondaryLinks[link] = true
+	}
+	return links, nil
+}
+
+func (s *failoverSource) FetchReport(ctx context.Context, link string, v interface{}) error {
+	if s.secondaryLinks[link] {
+		return s.Secondary.FetchReport(ctx, link, v)
+	}
+	return s.Primary.FetchReport(ctx, link, v)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+// ssmlReadings are the readings of words which speech engines often misread.
+var ssmlReadings = []struct {
+	word  string
+	alias string
+}{
+	{"夜のはじめ頃", "よるのはじめごろ"},
+	{"所により", "ところにより"},
+	{"朝のうち", "あさのうち"},
+	{"朝の内", "あさのうち"},
+	{"明け方", "あけがた"},
+	{"昼過ぎ", "ひるすぎ"},
+	{"昼前", "ひるまえ"},
+	{"昼頃", "ひるごろ"},
+	{"未明", "みめい"},
+	{"山沿い", "やまぞい"},
+	{"一時", "いちじ"},
+	{"時々", "ときどき"},
+	{"日中", "にっちゅう"},
+}
+
+// ssmlReplacer narrows full-width numbers, turns symbols into words and
+// drops the spaces between words, which make pauses, before the text is escaped.
+var ssmlReplacer = strings.NewReplacer(
+	"０", "0", "１", "1", "２", "2", "３", "3", "４", "4",
+	"５", "5", "６", "6", "７", "7", "８", "8", "９", "9",
+	"．", ".", "〜", "から", "～", "から", "%", "パーセント", "　", "", " ", "",
+)
+
+var ssmlReadingReplacer = func() *strings.Replacer {
+	var pairs []string
+	for _, r := range ssmlReadings {
+		pairs = append(pairs, r.word, fmt.Sprintf(`<sub alias="%s">%s</sub>`, r.alias, r.word))
+	}
+	return strings.NewReplacer(pairs...)
+}()
+
+// speakable returns s escaped for SSML with the readings of ssmlReadings.
+func speakable(s string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(ssmlReplacer.Replace(s)))
+	return ssmlReadingReplacer.Replace(buffer.String())
+}
+
+// speakTemp returns t in words, e.g. 25度, 氷点下3度 or 華氏77度.
+func speakTemp(t temp.Temp, u temp.Unit, p *dialect.Persona) string {
+	if u == temp.Fahrenheit && t.Valid() {
+		return "華氏" + t.Format(u) + "度"
+	}
+	return tempText(t, u, p)
+}
+
+// forecastSSML returns f as SSML in Japanese for speech, a sentence per line.
+// Sentences of the report are read as they are, without emoji,
+// by the standard persona.
+func forecastSSML(f Forecast) string {
+	p := personas[dialect.Standard]
+	wdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	day := "今日"
+	if f.Tomorrow {
+		day = "明日"
+	}
+
+	lowest := fmt.Sprintf(p.Phrases.Lowest, speakTemp(f.Temps.Low, f.Unit, p))
+	if c := compareText(f.Temps.Low, f.Temps.PrevLow, f.Temps.PrevName, f.Unit, p); c != "" {
+		lowest += "、" + c
+	}
+	highest := fmt.Sprintf(p.Phrases.Highest, speakTemp(f.Temps.High, f.Unit, p))
+	if c := compareText(f.Temps.High, f.Temps.PrevHigh, f.Temps.PrevName, f.Unit, p); c != "" {
+		highest += "、" + c
+	}
+
+	sentences := []string{
+		fmt.Sprintf("%sの%s、%s%s曜日の天気です。", f.Region.Name, day, f.Date.Format("1月2日"), wdays[f.Date.Weekday()]),
+		weatherSentence(f, p),
+		lowest,
+		highest,
+	}
+	if pop := generatePOP(f.POP, p); pop != "" {
+		sentences = append(sentences, pop)
+	}
+	if f.Wind != "" {
+		sentences = append(sentences, fmt.Sprintf(p.Phrases.Wind, p.Apply(f.Wind)))
+	}
+	if f.Wave != "" {
+		sentences = append(sentences, fmt.Sprintf(p.Phrases.Wave, p.Apply(f.Wave)))
+	}
+	if advice := adviceText(f.Advice, p); advice != "" {
+		sentences = append(sentences, advice)
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<speak version="1.1" xmlns="http://www.w3.org/2001/10/synthesis" xml:lang="ja-JP">` + "\n")
+	buffer.WriteString("  <p>\n")
+	for _, s := range sentences {
+		if !strings.HasSuffix(s, "。") {
+			s += "。"
+		}
+		fmt.Fprintf(&buffer, "    <s>%s</s>\n", speakable(s))
+	}
+	buffer.WriteString("  </p>\n")
+	buffer.WriteString("</speak>\n")
+	return buffer.String()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bamchoh/bam-weather/advice"
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func TestSpeakTemp(t *testing.T) {
+	p := personas[dialect.Standard]
+	tests := []struct {
+		t    temp.Temp
+		u    temp.Unit
+		want string
+	}{
+		{temp.New(25, temp.Celsius), temp.Celsius, "25度"},
+		{temp.New(0, temp.Celsius), temp.Celsius, "0度"},
+		{temp.New(-3, temp.Celsius), temp.Celsius, "氷点下3度"},
+		{temp.New(-0.4, temp.Celsius), temp.Celsius, "0度"},
+		{temp.New(25, temp.Celsius), temp.Fahrenheit, "華氏77度"},
+		{temp.New(-20, temp.Celsius), temp.Fahrenheit, "華氏-4度"},
+		{temp.Temp{}, temp.Celsius, "不明"},
+		{temp.Temp{}, temp.Fahrenheit, "不明"},
+	}
+	for _, tt := range tests {
+		if got := speakTemp(tt.t, tt.u, p); got != tt.want {
+			t.Errorf("speakTemp(%v, %v) = %q, want %q", tt.t, tt.u, got, tt.want)
+		}
+	}
+}
+
+func TestSpeakable(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"晴れ　時々　くもり", `晴れ<sub alias="ときどき">時々</sub>くもり`},
+		{"最高気温は ２５度", "最高気温は25度"},
+		{"確率は最大50%", "確率は最大50パーセント"},
+		{"3〜4メートル", "3から4メートル"},
+		{"<雨> & \"雷\"", "&lt;雨&gt;&amp;&#34;雷&#34;"},
+	}
+	for _, tt := range tests {
+		if got := speakable(tt.in); got != tt.want {
+			t.Errorf("speakable(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func ssmlForecast() Forecast {
+	region := regions["osaka"].clone()
+	region.Name = "大阪<府>&"
+	return Forecast{
+		Region: region,
+		Date:   time.Date(2020, 1, 10, 0, 0, 0, 0, time.Local),
+		Weather: WeatherForecastPart{
+			Base: WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+			Temporary: []WeatherInfo{
+				{TimeModifier: "時々", Weather: Weather{Type: "天気", Text: "くもり"}},
+			},
+		},
+		Temps: DayTemps{
+			Low: temp.New(-2, temp.Celsius), High: temp.New(8, temp.Celsius),
+			PrevLow: temp.New(1, temp.Celsius), PrevHigh: temp.New(8, temp.Celsius), PrevName: "昨日",
+		},
+		Unit: temp.Celsius,
+		POP:  [4]int{0, 0, 10, 40},
+		Wind: "北の風　後　北西の風",
+	}
+}
+
+func TestForecastSSML(t *testing.T) {
+	f := ssmlForecast()
+	got := forecastSSML(f)
+
+	var doc struct {
+		Sentences []string `xml:"p>s"`
+	}
+	if err := xml.Unmarshal([]byte(got), &doc); err != nil {
+		t.Fatalf("forecastSSML is not XML: %v\n%s", err, got)
+	}
+	if len(doc.Sentences) != 6 {
+		t.Errorf("forecastSSML has %d sentences, want 6\n%s", len(doc.Sentences), got)
+	}
+
+	for _, want := range []string{
+		"<s>大阪&lt;府&gt;&amp;の今日、1月10日金曜日の天気です。</s>",
+		"<s>最低気温は氷点下2度、昨日より3度低い。</s>",
+		"<s>最高気温は8度、昨日と同じくらい。</s>",
+		`<sub alias="ときどき">時々</sub>`,
+		"パーセント",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("forecastSSML does not contain %q\n%s", want, got)
+		}
+	}
+
+	f.Tomorrow = true
+	f.Unit = temp.Fahrenheit
+	got = forecastSSML(f)
+	for _, want := range []string{"の明日、", "最低気温は華氏28度", "最高気温は華氏46度", "昨日より6度低い"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("forecastSSML in Fahrenheit does not contain %q\n%s", want, got)
+		}
+	}
+}
+
+func TestForecastSSMLEmoji(t *testing.T) {
+	f := ssmlForecast()
+	f.Advice = []advice.Item{advice.FoldingUmbrella, advice.Coat}
+
+	// The osaka persona writes the weather in emoji, the speech must not.
+	c := Channel{Type: "test", Persona: dialect.Osaka}
+	if !strings.ContainsAny(c.forecastText(f), "☀☁") {
+		t.Fatalf("forecastText of %s has no emoji\n%s", c.Persona, c.forecastText(f))
+	}
+
+	got := forecastSSML(f)
+	for _, r := range got {
+		if (r >= 0x2600 && r <= 0x27bf) || r >= 0x1f000 {
+			t.Errorf("forecastSSML contains emoji %q\n%s", r, got)
+		}
+	}
+	if !strings.Contains(got, "<s>折りたたみ傘があると安心。コートが必要な寒さ。</s>") {
+		t.Errorf("forecastSSML has no advice\n%s", got)
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bamchoh/bam-weather/mys3"
+	"github.com/pkg/errors"
+)
+
+// errStoreNotFound is returned by Store.Get when the key does not exist.
+var errStoreNotFound = errors.New("key was not found")
+
+// Store keeps files such as archived reports and states of the bot.
+type Store interface {
+	Put(key, contentType string, data []byte) error
+	Get(key string) ([]byte, error)
+	// List returns the keys which start with prefix in lexical order.
+	List(prefix string) ([]string, error)
+}
+
+func newStore(cfg *Config) Store {
+	if cfg.Store.Dir != "" {
+		return &DirStore{Dir: cfg.Store.Dir}
+	}
+	return &S3Store{Bucket: cfg.Store.Bucket, Region: cfg.Store.Region}
+}
+
+// DirStore is a Store on a local directory.
+type DirStore struct {
+	Dir string
+}
+
+func (s *DirStore) Put(key, contentType string, data []byte) error {
+	path := filepath.Join(s.Dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "DirStore")
+	}
+	return errors.Wrap(ioutil.WriteFile(path, data, 0644), "DirStore")
+}
+
+func (s *DirStore) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(filepath.Join(s.Dir, filepath.FromSlash(key)))
+	if os.IsNotExist(err) {
+		return nil, errStoreNotFound
+	}
+	return data, errors.Wrap(err, "DirStore")
+}
+
+func (s *DirStore) List(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.Walk(s.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.Dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	sort.Strings(keys)
+	return keys, errors.Wrap(err, "DirStore")
+}
+
+// S3Store is a Store on a S3 bucket.
+type S3Store struct {
+	Bucket string
+	Region string
+}
+
+// Put uploads data, which is public only for the images and pages
+// linked from the posts. See publicKey.
+func (s *S3Store) Put(key, contentType string, data []byte) error {
+	return mys3.Upload(s.Bucket, s.Region, key, contentType, publicKey(key), bytes.NewReader(data))
+}
+
+// publicKey reports whether key is a published output, an image, a page or
+// the speech of the forecast. Archives, states, templates and replays are
+// private.
+func publicKey(key string) bool {
+	if strings.Contains(key, "/") {
+		return false
+	}
+	switch path.Ext(key) {
+	case ".png", ".html", ".ssml":
+		return true
+	}
+	return false
+}
+
+func (s *S3Store) Get(key string) ([]byte, error) {
+	data, err := mys3.Download(s.Bucket, s.Region, key)
+	if err == mys3.ErrNotFound {
+		return nil, errStoreNotFound
+	}
+	return data, err
+}
+
+func (s *S3Store) List(prefix string) ([]string, error) {
+	return mys3.List(s.Bucket, s.Region, prefix)
+}
@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestPublicKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		public bool
+	}{
+		{"weather.png", true},
+		{"hourly.png", true},
+		{"index.html", true},
+		{"warning.html", true},
+		{"forecast.ssml", true},
+		{"warnings.json", false},
+		{dailyPostKey, false},
+		{"archive/report/20200910/050000_270000_a.xml", false},
+		{"archive/feed/20200910/050000_regular_l.xml", false},
+		{"replay/20200910/weather.png", false},
+		{"templates/daily.tmpl", false},
+	}
+	for _, tt := range tests {
+		if got := publicKey(tt.key); got != tt.public {
+			t.Errorf("publicKey(%q) = %v, want %v", tt.key, got, tt.public)
+		}
+	}
+}
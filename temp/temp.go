@@ -0,0 +1,110 @@
+package temp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Unit is a unit of temperature.
+type Unit int
+
+const (
+	Celsius Unit = iota
+	Fahrenheit
+)
+
+// ParseUnit returns the unit of s, which is a unit attribute of JMA XML
+// ("度") or a name in the config ("celsius", "fahrenheit", "C", "F").
+func ParseUnit(s string) (Unit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "度", "℃", "°c", "c", "celsius":
+		return Celsius, nil
+	case "℉", "°f", "f", "fahrenheit":
+		return Fahrenheit, nil
+	}
+	return Celsius, fmt.Errorf("unit (%v) is not supported", s)
+}
+
+// Symbol returns the symbol of u, e.g. "°C".
+func (u Unit) Symbol() string {
+	if u == Fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// Temp is a temperature.
+// The zero value is a missing temperature, which is not forecasted.
+type Temp struct {
+	celsius float64
+	valid   bool
+}
+
+// New returns a temperature of v in u.
+func New(v float64, u Unit) Temp {
+	if u == Fahrenheit {
+		v = (v - 32) * 5 / 9
+	}
+	return Temp{celsius: v, valid: true}
+}
+
+// Parse parses the value s in the unit attribute unit.
+// An empty s is a missing temperature.
+func Parse(s, unit string) (Temp, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Temp{}, nil
+	}
+	u, err := ParseUnit(unit)
+	if err != nil {
+		return Temp{}, err
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Temp{}, fmt.Errorf("temperature (%v) is not a number", s)
+	}
+	return New(v, u), nil
+}
+
+// Valid reports whether t is forecasted.
+func (t Temp) Valid() bool {
+	return t.valid
+}
+
+// In returns the value of t in u.
+func (t Temp) In(u Unit) float64 {
+	if u == Fahrenheit {
+		return t.celsius*9/5 + 32
+	}
+	return t.celsius
+}
+
+// Round returns the value of t in u rounded to an integer.
+func (t Temp) Round(u Unit) int {
+	return int(math.Round(t.In(u)))
+}
+
+// Delta returns t - o in u. ok is false when either is missing.
+func (t Temp) Delta(o Temp, u Unit) (d int, ok bool) {
+	if !t.valid || !o.valid {
+		return 0, false
+	}
+	return t.Round(u) - o.Round(u), true
+}
+
+// Format returns the rounded value of t in u, or "--" when it is missing.
+func (t Temp) Format(u Unit) string {
+	if !t.valid {
+		return "--"
+	}
+	return strconv.Itoa(t.Round(u))
+}
+
+func (t Temp) String() string {
+	if !t.valid {
+		return "--"
+	}
+	return t.Format(Celsius) + Celsius.Symbol()
+}
@@ -0,0 +1,117 @@
+package temp
+
+import "testing"
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Unit
+		ok   bool
+	}{
+		{"", Celsius, true},
+		{"度", Celsius, true},
+		{" Celsius ", Celsius, true},
+		{"°C", Celsius, true},
+		{"F", Fahrenheit, true},
+		{"fahrenheit", Fahrenheit, true},
+		{"℉", Fahrenheit, true},
+		{"kelvin", Celsius, false},
+	}
+	for _, tt := range tests {
+		u, err := ParseUnit(tt.in)
+		if (err == nil) != tt.ok || u != tt.want {
+			t.Errorf("ParseUnit(%q) = %v, %v, want %v, ok %v", tt.in, u, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		s, unit string
+		valid   bool
+		c, f    string
+		ok      bool
+	}{
+		{"25", "度", true, "25", "77", true},
+		{" 0 ", "度", true, "0", "32", true},
+		{"-3", "度", true, "-3", "27", true},
+		{"-0.4", "度", true, "0", "31", true},
+		{"-0.6", "度", true, "-1", "31", true},
+		{"2.5", "度", true, "3", "37", true},
+		{"", "度", false, "--", "--", true},
+		{"  ", "度", false, "--", "--", true},
+		{"50", "F", true, "10", "50", true},
+		{"abc", "度", false, "--", "--", false},
+		{"25", "K", false, "--", "--", false},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.s, tt.unit)
+		if (err == nil) != tt.ok {
+			t.Errorf("Parse(%q, %q) error = %v", tt.s, tt.unit, err)
+			continue
+		}
+		if got.Valid() != tt.valid || got.Format(Celsius) != tt.c || got.Format(Fahrenheit) != tt.f {
+			t.Errorf("Parse(%q, %q) = %v %s/%s, want %v %s/%s", tt.s, tt.unit,
+				got.Valid(), got.Format(Celsius), got.Format(Fahrenheit), tt.valid, tt.c, tt.f)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		t    Temp
+		c, f float64
+	}{
+		{New(0, Celsius), 0, 32},
+		{New(100, Celsius), 100, 212},
+		{New(-40, Celsius), -40, -40},
+		{New(212, Fahrenheit), 100, 212},
+		{New(-4, Fahrenheit), -20, -4},
+	}
+	for _, tt := range tests {
+		if c, f := tt.t.In(Celsius), tt.t.In(Fahrenheit); c != tt.c || f != tt.f {
+			t.Errorf("%v: In = %v°C %v°F, want %v°C %v°F", tt.t, c, f, tt.c, tt.f)
+		}
+	}
+}
+
+func TestDelta(t *testing.T) {
+	tests := []struct {
+		t, o Temp
+		u    Unit
+		d    int
+		ok   bool
+	}{
+		{New(25, Celsius), New(22, Celsius), Celsius, 3, true},
+		{New(-2, Celsius), New(1, Celsius), Celsius, -3, true},
+		{New(25, Celsius), New(22, Celsius), Fahrenheit, 5, true},
+		{New(10.4, Celsius), New(9.6, Celsius), Celsius, 0, true},
+		{New(25, Celsius), Temp{}, Celsius, 0, false},
+		{Temp{}, New(25, Celsius), Celsius, 0, false},
+	}
+	for _, tt := range tests {
+		d, ok := tt.t.Delta(tt.o, tt.u)
+		if d != tt.d || ok != tt.ok {
+			t.Errorf("%v.Delta(%v, %v) = %d, %v, want %d, %v", tt.t, tt.o, tt.u, d, ok, tt.d, tt.ok)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		t    Temp
+		want string
+	}{
+		{New(18, Celsius), "18°C"},
+		{New(-5, Celsius), "-5°C"},
+		{Temp{}, "--"},
+	}
+	for _, tt := range tests {
+		if got := tt.t.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+	if Celsius.Symbol() != "°C" || Fahrenheit.Symbol() != "°F" {
+		t.Errorf("Symbol() = %q, %q", Celsius.Symbol(), Fahrenheit.Symbol())
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bamchoh/bam-weather/genpng"
+)
+
+// Outputs whose theme can be chosen by the options.
+const (
+	outputDaily  = "daily"
+	outputWeekly = "weekly"
+	outputHourly = "hourly"
+)
+
+// themes are the backgrounds of the images by name.
+// The built-in ones are overridden by the themes of the config.
+var themes = genpng.Themes()
+
+// checkImageThemes returns an error when an output or a theme is unknown.
+func checkImageThemes(opts Options) error {
+	for output, name := range opts.ImageThemes {
+		switch output {
+		case outputDaily, outputWeekly, outputHourly:
+		default:
+			return fmt.Errorf("output (%v) is not supported", output)
+		}
+		if _, ok := themes[name]; !ok {
+			return fmt.Errorf("theme (%v) is not found", name)
+		}
+	}
+	return nil
+}
+
+// imageLook returns the background of the output for date,
+// in the night palette when night is set.
+// The flat theme is used when the options choose none.
+func imageLook(opts Options, output string, date time.Time, night bool) genpng.Look {
+	return genpng.Look{
+		Theme: themes[opts.ImageThemes[output]],
+		Night: night,
+		Month: date.Month(),
+	}
+}
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/bamchoh/bam-weather/genpng"
@@ -14,13 +12,18 @@ import (
 
 type TodayWeatherGenerator struct {
 	BaseTime    time.Time
-	text        string
+	Source      ForecastSource
+	Region      Region
+	Options     Options
+	report      ReportRef
+	forecast    Forecast
 	weatherInfo genpng.WeatherInfo
+	hourlyChart genpng.HourlyChart
 }
 
-func (gen *TodayWeatherGenerator) Init() error {
+func (gen *TodayWeatherGenerator) Init(ctx context.Context) error {
 	tt := gen.BaseTime
-	today, err := gen.getDayInfo(tt.Add(-6*time.Hour), tt)
+	today, ref, err := gen.getDayInfo(ctx, tt.Add(-6*time.Hour), tt)
 	if err != nil {
 		err = errors.Wrap(err, "failed to get today info")
 		log.Println(err)
@@ -28,98 +31,78 @@ func (gen *TodayWeatherGenerator) Init() error {
 	}
 
 	tt2 := tt.Add(-24 * time.Hour)
-	yesterday, err := gen.getDayInfo(tt2.Add(-6*time.Hour), tt2)
+	yesterday, _, err := gen.getDayInfo(ctx, tt2.Add(-6*time.Hour), tt2)
 	if err != nil {
-		err = errors.Wrap(err, "failed to get yesterday info")
-		log.Println(err)
-		return err
+		if errors.Cause(err) != errLinkNotFound {
+			err = errors.Wrap(err, "failed to get yesterday info")
+			log.Println(err)
+			return err
+		}
+		// e.g. the JMA forecast API only has the latest report
+		log.Println("yesterday report was not found, the lowest is unknown")
+		yesterday = &DayInfo{}
+	}
+
+	// the lowest of yesterday is in the report of the day before
+	temps := DayTemps{
+		Low:      yesterday.TempL,
+		High:     today.TempH,
+		PrevHigh: yesterday.TempH,
+		PrevName: "昨日",
+	}
+	tt3 := tt.Add(-48 * time.Hour)
+	if before, _, err := gen.getDayInfo(ctx, tt3.Add(-6*time.Hour), tt3); err != nil {
+		log.Println("failed to get the day before yesterday info:", err)
+	} else {
+		temps.PrevLow = before.TempL
 	}
 
 	log.Println(today.Weather)
+	gen.report = ref
 
-	when := fmt.Sprintf("今日(%s)", gen.Day().Format("1月2日"))
-	gen.text = generateForecast(today.Weather, yesterday.TempL, today.TempH, when)
-	gen.text += fmt.Sprintf("\n%v?%d", indexURL, time.Now().Unix())
+	gen.forecast = newForecast(gen.Region, today, temps, gen.Day(), false, gen.Options)
+	gen.forecast.URL = fmt.Sprintf("%v?%d", indexURL, time.Now().Unix())
 
-	gen.weatherInfo = genWeatherInfo(today, yesterday.TempL, today.TempH)
+	gen.weatherInfo = genWeatherInfo(today, temps, gen.Options)
+	gen.hourlyChart = genHourlyChart(today.Hourly, gen.Options.Unit())
 	return nil
 }
 
-func (gen *TodayWeatherGenerator) getDayInfo(sday, eday time.Time) (*DayInfo, error) {
-	link, err := getXMLLink2(sday, eday)
+func (gen *TodayWeatherGenerator) getDayInfo(ctx context.Context, sday, eday time.Time) (*DayInfo, ReportRef, error) {
+	ref, err := findReport(ctx, gen.Source, "府県天気予報", sday, eday)
 	if err != nil {
-		return nil, err
+		return nil, ref, err
 	}
 
-	return gen.getWeatherReport(link)
+	day, err := gen.getWeatherReport(ctx, ref.Link)
+	return day, ref, err
 }
 
-func (gen *TodayWeatherGenerator) getWeatherReport(path string) (*DayInfo, error) {
-	resp, err := http.Get(path)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	dec := xml.NewDecoder(resp.Body)
+func (gen *TodayWeatherGenerator) getWeatherReport(ctx context.Context, path string) (*DayInfo, error) {
 	var v Report
-	if err := dec.Decode(&v); err != nil {
+	if err := gen.Source.FetchReport(ctx, path, &v); err != nil {
 		return nil, err
 	}
 
-	var di DayInfo
-	if len(v.Body.MeteorologicalInfos) > 0 {
-		info := v.Body.MeteorologicalInfos[0]
-		if len(info.Items) > 0 {
-			item := info.Items[0]
-			if len(item.Kinds) > 0 {
-				kind := item.Kinds[0]
-				if len(kind.WeatherForecasts) > 0 {
-					for _, w := range kind.WeatherForecasts {
-						if w.ID == "1" {
-							di.Weather = w
-							break
-						}
-					}
-				}
-			}
-		}
-	}
+	return newDayInfo(&v, gen.Region, "1", "今日日中")
+}
 
-	if len(v.Body.MeteorologicalInfos) > 0 {
-		for _, info := range v.Body.MeteorologicalInfos {
-			if info.Type == "地点予報" {
-				for _, def := range info.TimeDefines {
-					switch def.Name {
-					case "明日朝":
-						id, err := strconv.Atoi(def.ID)
-						if err != nil {
-							return nil, err
-						}
-						di.TempL = info.Items[0].Kinds[id-1].TemperaturePart.Temperature.Description
-					case "今日日中":
-						id, err := strconv.Atoi(def.ID)
-						if err != nil {
-							return nil, err
-						}
-						di.TempH = info.Items[0].Kinds[id-1].TemperaturePart.Temperature.Description
-					}
-				}
-				break
-			}
-		}
-	}
-	return &di, nil
+func (gen *TodayWeatherGenerator) Report() ReportRef {
+	return gen.report
 }
 
-func (gen *TodayWeatherGenerator) Text() string {
-	return gen.text
+func (gen *TodayWeatherGenerator) Forecast() Forecast {
+	return gen.forecast
 }
 
 func (gen *TodayWeatherGenerator) WeatherInfo() genpng.WeatherInfo {
 	return gen.weatherInfo
 }
 
+func (gen *TodayWeatherGenerator) HourlyChart() genpng.HourlyChart {
+	return gen.hourlyChart
+}
+
 func (gen *TodayWeatherGenerator) Day() time.Time {
 	return gen.BaseTime
 }
@@ -1,11 +1,9 @@
 package main
 
 import (
-	"encoding/xml"
+	"context"
 	"fmt"
 	"log"
-	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/bamchoh/bam-weather/genpng"
@@ -14,103 +12,84 @@ import (
 
 type TomorrowWeatherGenerator struct {
 	BaseTime    time.Time
-	text        string
+	Source      ForecastSource
+	Region      Region
+	Options     Options
+	report      ReportRef
+	forecast    Forecast
 	weatherInfo genpng.WeatherInfo
+	hourlyChart genpng.HourlyChart
 }
 
-func (gen *TomorrowWeatherGenerator) getDayInfo(sday, eday time.Time) (*DayInfo, error) {
-	link, err := getXMLLink2(sday, eday)
+func (gen *TomorrowWeatherGenerator) getDayInfo(ctx context.Context, sday, eday time.Time) (*DayInfo, ReportRef, error) {
+	ref, err := findReport(ctx, gen.Source, "府県天気予報", sday, eday)
 	if err != nil {
-		return nil, err
+		return nil, ref, err
 	}
 
-	return gen.getWeatherReport(link)
+	day, err := gen.getWeatherReport(ctx, ref.Link)
+	return day, ref, err
 }
 
-func (gen *TomorrowWeatherGenerator) getWeatherReport(path string) (*DayInfo, error) {
-	resp, err := http.Get(path)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	dec := xml.NewDecoder(resp.Body)
+func (gen *TomorrowWeatherGenerator) getWeatherReport(ctx context.Context, path string) (*DayInfo, error) {
 	var v Report
-	if err := dec.Decode(&v); err != nil {
+	if err := gen.Source.FetchReport(ctx, path, &v); err != nil {
 		return nil, err
 	}
 
-	var di DayInfo
-	if len(v.Body.MeteorologicalInfos) > 0 {
-		info := v.Body.MeteorologicalInfos[0]
-		if len(info.Items) > 0 {
-			item := info.Items[0]
-			if len(item.Kinds) > 0 {
-				kind := item.Kinds[0]
-				if len(kind.WeatherForecasts) > 0 {
-					for _, w := range kind.WeatherForecasts {
-						if w.ID == "2" {
-							di.Weather = w
-							break
-						}
-					}
-				}
-			}
-		}
-	}
-
-	if len(v.Body.MeteorologicalInfos) > 0 {
-		for _, info := range v.Body.MeteorologicalInfos {
-			if info.Type == "地点予報" {
-				for _, def := range info.TimeDefines {
-					switch def.Name {
-					case "明日朝":
-						id, err := strconv.Atoi(def.ID)
-						if err != nil {
-							return nil, err
-						}
-						di.TempL = info.Items[0].Kinds[id-1].TemperaturePart.Temperature.Description
-					case "明日日中":
-						id, err := strconv.Atoi(def.ID)
-						if err != nil {
-							return nil, err
-						}
-						di.TempH = info.Items[0].Kinds[id-1].TemperaturePart.Temperature.Description
-					}
-				}
-				break
-			}
-		}
-	}
-	return &di, nil
+	return newDayInfo(&v, gen.Region, "2", "明日日中")
 }
 
-func (gen *TomorrowWeatherGenerator) Init() error {
+func (gen *TomorrowWeatherGenerator) Init(ctx context.Context) error {
 	bt := gen.BaseTime
-	tomorrow, err := gen.getDayInfo(bt.Add(-6*time.Hour), bt)
+	tomorrow, ref, err := gen.getDayInfo(ctx, bt.Add(-6*time.Hour), bt)
 	if err != nil {
-		err = errors.Wrap(err, "failed to get today info")
+		err = errors.Wrap(err, "failed to get tomorrow info")
 		log.Println(err)
 		return err
 	}
+	temps := DayTemps{
+		Low:      tomorrow.TempL,
+		High:     tomorrow.TempH,
+		PrevName: "今日",
+	}
+	// the evening report of yesterday has today's temperatures
+	// at the same time defines as tomorrow's
+	bt2 := bt.Add(-24 * time.Hour)
+	if today, _, err := gen.getDayInfo(ctx, bt2.Add(-6*time.Hour), bt2); err != nil {
+		log.Println("failed to get today info:", err)
+	} else {
+		temps.PrevLow = today.TempL
+		temps.PrevHigh = today.TempH
+	}
+
 	log.Println(tomorrow.Weather)
+	gen.report = ref
 
-	when := fmt.Sprintf("明日(%s)", gen.Day().Format("1月2日"))
-	gen.text = generateForecast(tomorrow.Weather, tomorrow.TempL, tomorrow.TempH, when)
-	gen.text += fmt.Sprintf("\n%v?%d", indexURL, gen.BaseTime.Unix())
+	gen.forecast = newForecast(gen.Region, tomorrow, temps, gen.Day(), true, gen.Options)
+	gen.forecast.URL = fmt.Sprintf("%v?%d", indexURL, gen.BaseTime.Unix())
 
-	gen.weatherInfo = genWeatherInfo(tomorrow, tomorrow.TempL, tomorrow.TempH)
+	gen.weatherInfo = genWeatherInfo(tomorrow, temps, gen.Options)
+	gen.hourlyChart = genHourlyChart(tomorrow.Hourly, gen.Options.Unit())
 	return nil
 }
 
-func (gen *TomorrowWeatherGenerator) Text() string {
-	return gen.text
+func (gen *TomorrowWeatherGenerator) Report() ReportRef {
+	return gen.report
+}
+
+func (gen *TomorrowWeatherGenerator) Forecast() Forecast {
+	return gen.forecast
 }
 
 func (gen *TomorrowWeatherGenerator) WeatherInfo() genpng.WeatherInfo {
 	return gen.weatherInfo
 }
 
+func (gen *TomorrowWeatherGenerator) HourlyChart() genpng.HourlyChart {
+	return gen.hourlyChart
+}
+
 func (gen *TomorrowWeatherGenerator) Day() time.Time {
 	return gen.BaseTime.Add(24 * time.Hour)
 }
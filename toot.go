@@ -1,25 +1,96 @@
 package main
 
 import (
+	"bytes"
 	"context"
-	"log"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strings"
 
 	mastodon "github.com/mattn/go-mastodon"
 )
 
-func toot(text string) {
-	c := mastodon.NewClient(&mastodon.Config{
+// tootThread posts texts as a thread, each replying to the previous one.
+// Images are attached to the first toot with their alt text.
+func tootThread(texts []string, images ...media) error {
+	cfg := &mastodon.Config{
 		Server:       MastodonServer,
 		ClientID:     ClientID,
 		ClientSecret: ClientSecret,
-	})
+	}
+	c := mastodon.NewClient(cfg)
 	err := c.Authenticate(context.Background(), MastodonUser, MastodonPass)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+
+	var ids []mastodon.ID
+	for _, img := range images {
+		if img.data == nil {
+			continue
+		}
+		id, err := uploadMedia(c, cfg, img)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	var replyTo mastodon.ID
+	for i, text := range texts {
+		toot := mastodon.Toot{Status: text, Visibility: "unlisted", InReplyToID: replyTo}
+		if i == 0 {
+			toot.MediaIDs = ids
+		}
+		status, err := c.PostStatus(context.Background(), &toot)
+		if err != nil {
+			return err
+		}
+		replyTo = status.ID
 	}
-	toot := mastodon.Toot{Status: text, Visibility: "unlisted"}
-	_, err = c.PostStatus(context.Background(), &toot)
+	return nil
+}
+
+// uploadMedia uploads img with its alt text as the description.
+// go-mastodon cannot send the description, so the form is posted here.
+func uploadMedia(c *mastodon.Client, cfg *mastodon.Config, img media) (mastodon.ID, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "image.png")
 	if err != nil {
-		log.Fatal(err)
+		return "", err
+	}
+	if _, err := fw.Write(img.data); err != nil {
+		return "", err
+	}
+	if err := mw.WriteField("description", img.alt); err != nil {
+		return "", err
+	}
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimRight(cfg.Server, "/")+"/api/v1/media", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+cfg.AccessToken)
+
+	res, err := c.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return "", fmt.Errorf("failed to upload media (%v)", res.Status)
+	}
+
+	var attachment mastodon.Attachment
+	if err := json.NewDecoder(res.Body).Decode(&attachment); err != nil {
+		return "", err
 	}
+	return attachment.ID, nil
 }
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bamchoh/bam-weather/genpng"
+	"github.com/bamchoh/bam-weather/i18n"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/bamchoh/bam-weather/weathercode"
+)
+
+// lookupLang returns the language of code. ok is false for Japanese.
+func lookupLang(code string) (*i18n.Lang, bool, error) {
+	if code == "" || code == "ja" {
+		return nil, false, nil
+	}
+	l, ok := i18n.Lookup(code)
+	if !ok {
+		return nil, false, fmt.Errorf("language (%v) is not supported", code)
+	}
+	return l, true, nil
+}
+
+// translateWeather returns the weather sentences of f in l.
+// The weather code is used instead when a word is not in the dictionary.
+func translateWeather(f Forecast, l *i18n.Lang) string {
+	var ws []WeatherInfo
+	wf := f.Weather
+	ws = append(ws, wf.Base)
+	ws = append(ws, wf.Temporary...)
+	ws = append(ws, wf.Becoming...)
+
+	// time modifiers other than 時々 and 後 start a new sentence
+	searchText := []string{"時々", "後"}
+	var sentences []string
+	prefix := ""
+	var words []string
+	flush := func() bool {
+		s, ok := l.Translate(strings.Join(words, " "))
+		sentences = append(sentences, prefix+s)
+		return ok
+	}
+	for _, w := range ws {
+		if w.TimeModifier == "" || w.Exists(searchText) {
+			words = append(words, w.TimeModifier, w.Weather.Text)
+			continue
+		}
+		if !flush() {
+			return translateWeatherCode(f.WeatherCode, l)
+		}
+		mod, ok := l.Translate(w.TimeModifier)
+		if !ok {
+			return translateWeatherCode(f.WeatherCode, l)
+		}
+		prefix = mod + l.Phrases.Comma
+		words = []string{w.Weather.Text}
+	}
+	if !flush() {
+		return translateWeatherCode(f.WeatherCode, l)
+	}
+
+	if wf.SubArea.Sentence != "" {
+		if s, ok := l.Translate(wf.SubArea.Sentence); ok {
+			sentences = append(sentences, s)
+		}
+	}
+	return l.Sentences(sentences)
+}
+
+// translateWeatherCode returns the icons of the weather code in l.
+func translateWeatherCode(code string, l *i18n.Lang) string {
+	t, ok := weathercode.Lookup(code)
+	if !ok {
+		return l.Phrases.Unknown
+	}
+	s, ok := l.Translate(strings.Join([]string{t.Primary, t.Connector, t.Secondary}, " "))
+	if !ok {
+		return l.Phrases.Unknown
+	}
+	return l.Sentences([]string{s})
+}
+
+func translateTemp(t temp.Temp, u temp.Unit, l *i18n.Lang) string {
+	if !t.Valid() {
+		return l.Phrases.Unknown
+	}
+	return t.Format(u) + u.Symbol()
+}
+
+// translateCompare returns the difference from the day before in l.
+// It is empty when either is missing.
+func translateCompare(t, prev temp.Temp, prevName string, u temp.Unit, l *i18n.Lang) string {
+	d, ok := t.Delta(prev, u)
+	if !ok {
+		return ""
+	}
+	name, _ := l.Translate(prevName)
+	switch {
+	case d > 0:
+		return fmt.Sprintf(l.Phrases.Higher, name, d)
+	case d < 0:
+		return fmt.Sprintf(l.Phrases.Lower, name, -d)
+	}
+	return fmt.Sprintf(l.Phrases.Same, name)
+}
+
+// translateForecast returns the text of f in l.
+// Wind and waves are left out when they cannot be translated.
+func translateForecast(f Forecast, l *i18n.Lang) string {
+	day := l.Phrases.Today
+	if f.Tomorrow {
+		day = l.Phrases.Tomorrow
+	}
+
+	temps := f.Temps
+	lowest := fmt.Sprintf(l.Phrases.Lowest, translateTemp(temps.Low, f.Unit, l))
+	if c := translateCompare(temps.Low, temps.PrevLow, temps.PrevName, f.Unit, l); c != "" {
+		lowest += l.Phrases.Comma + c
+	}
+	highest := fmt.Sprintf(l.Phrases.Highest, translateTemp(temps.High, f.Unit, l))
+	if c := translateCompare(temps.High, temps.PrevHigh, temps.PrevName, f.Unit, l); c != "" {
+		highest += l.Phrases.Comma + c
+	}
+
+	lines := []string{
+		fmt.Sprintf(l.Phrases.Intro, f.Region.NameIn(l.Code), day, l.Day(f.Date), translateWeather(f, l)),
+		lowest,
+		highest,
+	}
+
+	if start, peak := popSpan(f.POP); start >= 0 {
+		lines = append(lines, fmt.Sprintf(l.Phrases.POP, l.Phrases.Periods[start], peak))
+	}
+	var sentences []string
+	for _, item := range f.Advice {
+		sentences = append(sentences, l.Phrases.Advice[item])
+	}
+	if s := strings.Join(sentences, l.Sep); s != "" {
+		lines = append(lines, s)
+	}
+	if s, ok := l.Translate(f.Wind); ok && s != "" {
+		lines = append(lines, fmt.Sprintf(l.Phrases.Wind, s))
+	}
+	if s, ok := l.Translate(f.Wave); ok && s != "" {
+		lines = append(lines, fmt.Sprintf(l.Phrases.Wave, s))
+	}
+	if l.Phrases.Tag != "" {
+		lines = append(lines, l.Phrases.Tag)
+	}
+	if f.URL != "" {
+		lines = append(lines, f.URL)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// imageText returns s translated for images, without leading commas.
+// English is used when the font cannot draw the language.
+// ok is false when s cannot be translated.
+func imageText(s string, l *i18n.Lang) (string, bool) {
+	for _, lang := range []*i18n.Lang{l, imageLang(l)} {
+		if t, ok := lang.Translate(s); ok && genpng.CanDraw(t) {
+			return strings.TrimLeft(t, ",， "), true
+		}
+	}
+	return "", false
+}
+
+// localizeWeatherInfo translates the labels of info into the language of opts.
+func localizeWeatherInfo(info genpng.WeatherInfo, opts Options) genpng.WeatherInfo {
+	l, ok, _ := lookupLang(opts.Language)
+	if !ok {
+		return info
+	}
+	info.Alt = translateAlt(info, l)
+	if t, ok := imageText(info.Second, l); ok {
+		info.Second = t
+	}
+	if t, ok := imageText(info.Fourth, l); ok {
+		info.Fourth = t
+	}
+	il := imageLang(l)
+	info.HighLabel = il.Phrases.HighLabel
+	info.LowLabel = il.Phrases.LowLabel
+	return info
+}
+
+// imageLang returns l, or English when the font cannot draw the weekdays of l.
+func imageLang(l *i18n.Lang) *i18n.Lang {
+	if genpng.CanDraw(strings.Join(l.Weekdays[:], "") + l.Phrases.HighLabel + l.Phrases.LowLabel) {
+		return l
+	}
+	en, _ := i18n.Lookup("en")
+	return en
+}
+
+// translateAlt returns the alt text of the weather image in l,
+// e.g. Sunny, later cloudy, High 18°C, Low 9°C.
+// The weather is left out when it cannot be translated.
+func translateAlt(info genpng.WeatherInfo, l *i18n.Lang) string {
+	var parts []string
+	if s, ok := l.Translate(strings.Join([]string{info.First, info.Second, info.Third, info.Fourth, info.Fifth}, " ")); ok {
+		parts = append(parts, strings.TrimLeft(s, ",， "))
+	}
+	if info.High.Valid() {
+		parts = append(parts, fmt.Sprintf(l.Phrases.Highest, translateTemp(info.High, info.Unit, l)))
+	}
+	if info.Low.Valid() {
+		parts = append(parts, fmt.Sprintf(l.Phrases.Lowest, translateTemp(info.Low, info.Unit, l)))
+	}
+	return l.Sentences([]string{strings.Join(parts, l.Phrases.Comma)})
+}
+
+// hourlyAlt returns the alt text of the hourly chart in the language of opts.
+func hourlyAlt(chart genpng.HourlyChart, opts Options) string {
+	l, ok, _ := lookupLang(opts.Language)
+	if !ok {
+		return chart.AltText()
+	}
+	var slots []string
+	for _, s := range chart.Slots {
+		parts := []string{s.Label}
+		if w, ok := l.Translate(s.Weather); ok && w != "" {
+			parts = append(parts, w)
+		}
+		if s.Temp.Valid() {
+			parts = append(parts, translateTemp(s.Temp, chart.Unit, l))
+		}
+		slots = append(slots, strings.Join(parts, " "))
+	}
+	return strings.Join(slots, l.Phrases.Comma)
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestTranslateWeather(t *testing.T) {
+	hail := WeatherForecastPart{
+		Base:      WeatherInfo{Weather: Weather{Type: "天気", Text: "晴れ"}},
+		Temporary: []WeatherInfo{{TimeModifier: "時々", Weather: Weather{Type: "天気", Text: "ひょう"}}},
+	}
+	tests := []struct {
+		name       string
+		f          Forecast
+		en, zh, ko string
+	}{
+		{
+			"thunder",
+			Forecast{Weather: parseWeatherSentence("くもり　時々　雨　で　雷を伴う"), WeatherCode: "202"},
+			"Cloudy, at times rain, with thunder.", "多云，有时雨，伴有雷。", "흐림 때때로 비, 천둥 동반.",
+		},
+		{
+			"from evening and sub-area",
+			Forecast{Weather: parseWeatherSentence("晴れ　夕方　から　くもり　所により　夜　雨"), WeatherCode: "110"},
+			"Sunny. From evening, cloudy. In places at night rain.",
+			"晴。傍晚起，多云。局部地区夜间雨。",
+			"맑음. 저녁부터, 흐림. 곳에 따라 밤 비.",
+		},
+		{
+			"place before では",
+			Forecast{Weather: parseWeatherSentence("晴れ　時々　くもり　山沿い　では　雨"), WeatherCode: "101"},
+			"Sunny, at times cloudy. Near the mountains: rain.",
+			"晴，有时多云。山区附近雨。",
+			"맑음 때때로 흐림. 산간에서는 비.",
+		},
+		{
+			"unknown word falls back to the weather code",
+			Forecast{Weather: hail, WeatherCode: "101"},
+			"Sunny, at times cloudy.", "晴，有时多云。", "맑음 때때로 흐림.",
+		},
+		{
+			"unknown weather code",
+			Forecast{Weather: hail, WeatherCode: "999"},
+			"unknown", "未知", "알 수 없음",
+		},
+	}
+	for _, tt := range tests {
+		for code, want := range map[string]string{"en": tt.en, "zh": tt.zh, "ko": tt.ko} {
+			l, _, _ := lookupLang(code)
+			if got := translateWeather(tt.f, l); got != want {
+				t.Errorf("%s (%s): translateWeather() = %q, want %q", tt.name, code, got, want)
+			}
+		}
+	}
+}
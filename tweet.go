@@ -1,14 +1,98 @@
 package main
 
-import "github.com/ChimeraCoder/anaconda"
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
-func tweet(text string) error {
+	"github.com/ChimeraCoder/anaconda"
+	"github.com/garyburd/go-oauth/oauth"
+)
+
+const mediaMetadataURL = "https://upload.twitter.com/1.1/media/metadata/create.json"
+
+// tweetThread posts texts as a thread, each replying to the previous one.
+// Images are attached to the first tweet with their alt text.
+func tweetThread(texts []string, images ...media) error {
 	anaconda.SetConsumerKey(ConsumerKey)
 	anaconda.SetConsumerSecret(ConsumerSecret)
 	api := anaconda.NewTwitterApi(APIKey, APISecret)
-	_, err := api.PostTweet(text, nil)
+
+	var ids []string
+	for _, img := range images {
+		if img.data == nil {
+			continue
+		}
+		m, err := api.UploadMedia(base64.StdEncoding.EncodeToString(img.data))
+		if err != nil {
+			return err
+		}
+		if err := setAltText(m.MediaIDString, img.alt); err != nil {
+			return err
+		}
+		ids = append(ids, m.MediaIDString)
+	}
+
+	replyTo := ""
+	for i, text := range texts {
+		v := url.Values{}
+		if i == 0 && len(ids) > 0 {
+			v.Set("media_ids", strings.Join(ids, ","))
+		}
+		if replyTo != "" {
+			v.Set("in_reply_to_status_id", replyTo)
+			v.Set("auto_populate_reply_metadata", "true")
+		}
+		t, err := api.PostTweet(text, v)
+		if err != nil {
+			return err
+		}
+		replyTo = t.IdStr
+	}
+	return nil
+}
+
+// setAltText sets the alt text of the uploaded media.
+// anaconda cannot post the JSON body of media/metadata/create, so the request
+// is signed here. Twitter accepts up to 1000 characters.
+func setAltText(id, alt string) error {
+	if alt == "" {
+		return nil
+	}
+	if r := []rune(alt); len(r) > 1000 {
+		alt = string(r[:1000])
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"media_id": id,
+		"alt_text": map[string]string{"text": alt},
+	})
 	if err != nil {
 		return err
 	}
+	req, err := http.NewRequest(http.MethodPost, mediaMetadataURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := oauth.Client{Credentials: oauth.Credentials{Token: ConsumerKey, Secret: ConsumerSecret}}
+	err = client.SetAuthorizationHeader(req.Header, &oauth.Credentials{Token: APIKey, Secret: APISecret}, req.Method, req.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("failed to set alt text (%v)", res.Status)
+	}
 	return nil
 }
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bamchoh/bam-weather/genpng"
+	"github.com/pkg/errors"
+)
+
+const (
+	warningTitle    = "気象警報・注意報"
+	warningStateKey = "warnings.json"
+)
+
+type WarningReport struct {
+	Control Control
+	Head    Head
+	Body    WarningBody
+}
+
+type WarningBody struct {
+	Warnings []Warning `xml:"Warning"`
+}
+
+type Warning struct {
+	Type  string        `xml:"type,attr"`
+	Items []WarningItem `xml:"Item"`
+}
+
+type WarningItem struct {
+	Kinds []WarningKind `xml:"Kind"`
+	Area  Area
+}
+
+type WarningKind struct {
+	Name   string
+	Code   string
+	Status string
+}
+
+// WarningState is the warnings in effect which were seen last time.
+type WarningState struct {
+	ReportDateTime string   `json:"report_datetime"`
+	Kinds          []string `json:"kinds"`
+}
+
+// WarningNotice is a change of a warning.
+// From is set when a warning is switched from another level.
+type WarningNotice struct {
+	Name   string
+	From   string
+	Status string
+}
+
+const (
+	warningIssued    = "発表"
+	warningSwitched  = "切替"
+	warningCancelled = "解除"
+)
+
+var warningSuffixes = []string{"特別警報", "警報", "注意報"}
+
+// warningPhenomenon returns the phenomenon of a warning (e.g. 大雨 of 大雨警報).
+func warningPhenomenon(name string) string {
+	for _, s := range warningSuffixes {
+		if strings.HasSuffix(name, s) {
+			return strings.TrimSuffix(name, s)
+		}
+	}
+	return name
+}
+
+// warningLevel returns 3 for 特別警報, 2 for 警報, 1 for 注意報.
+func warningLevel(name string) int {
+	for i, s := range warningSuffixes {
+		if strings.HasSuffix(name, s) {
+			return len(warningSuffixes) - i
+		}
+	}
+	return 0
+}
+
+// warningsInEffect returns the names of the warnings in effect
+// for the 府県予報区 of code.
+func warningsInEffect(v *WarningReport, code string) ([]string, error) {
+	for _, w := range v.Body.Warnings {
+		if w.Type != "気象警報・注意報（府県予報区等）" {
+			continue
+		}
+		for _, item := range w.Items {
+			if item.Area.Code != code {
+				continue
+			}
+			var kinds []string
+			for _, kind := range item.Kinds {
+				switch kind.Status {
+				case "解除", "発表警報・注意報はなし":
+					continue
+				}
+				if kind.Name != "" {
+					kinds = append(kinds, kind.Name)
+				}
+			}
+			sort.Strings(kinds)
+			return kinds, nil
+		}
+	}
+	return nil, fmt.Errorf("area (%v) was not found in warnings", code)
+}
+
+func diffWarnings(prev, cur []string) []WarningNotice {
+	prevSet := map[string]bool{}
+	for _, k := range prev {
+		prevSet[k] = true
+	}
+	curSet := map[string]bool{}
+	for _, k := range cur {
+		curSet[k] = true
+	}
+
+	var notices []WarningNotice
+	switched := map[string]bool{}
+	for _, k := range cur {
+		if prevSet[k] {
+			continue
+		}
+		notice := WarningNotice{Name: k, Status: warningIssued}
+		for _, p := range prev {
+			if !curSet[p] && warningPhenomenon(p) == warningPhenomenon(k) {
+				notice.From = p
+				notice.Status = warningSwitched
+				switched[p] = true
+				break
+			}
+		}
+		notices = append(notices, notice)
+	}
+
+	for _, k := range prev {
+		if !curSet[k] && !switched[k] {
+			notices = append(notices, WarningNotice{Name: k, Status: warningCancelled})
+		}
+	}
+	return notices
+}
+
+// warningPhrases are the phrases of a warning text in a persona or a language.
+type warningPhrases struct {
+	intro, issued, upgraded, downgraded, cancelled, tag string
+}
+
+// warningText returns the text of notices for the channel.
+// Names of warnings are translated for a channel with a language,
+// and kept in Japanese when a word is not in the dictionary.
+func (c Channel) warningText(region Region, notices []WarningNotice) string {
+	name := func(s string) string { return s }
+	var ph warningPhrases
+	if l, ok, _ := lookupLang(c.Language); ok {
+		region.Name = region.NameIn(l.Code)
+		name = func(s string) string {
+			if t, ok := l.Translate(s); ok {
+				return t
+			}
+			return s
+		}
+		p := l.Phrases
+		ph = warningPhrases{p.WarningIntro, p.WarningIssued, p.WarningUpgraded, p.WarningDowngraded, p.WarningCancelled, p.Tag}
+	} else {
+		p := c.phrases()
+		ph = warningPhrases{p.WarningIntro, p.WarningIssued, p.WarningUpgraded, p.WarningDowngraded, p.WarningCancelled, p.Tag}
+	}
+
+	lines := []string{fmt.Sprintf(ph.intro, region.Name)}
+	for _, n := range notices {
+		switch n.Status {
+		case warningIssued:
+			lines = append(lines, fmt.Sprintf(ph.issued, name(n.Name)))
+		case warningSwitched:
+			if warningLevel(n.Name) > warningLevel(n.From) {
+				lines = append(lines, fmt.Sprintf(ph.upgraded, name(n.From), name(n.Name)))
+			} else {
+				lines = append(lines, fmt.Sprintf(ph.downgraded, name(n.From), name(n.Name)))
+			}
+		case warningCancelled:
+			lines = append(lines, fmt.Sprintf(ph.cancelled, name(n.Name)))
+		}
+	}
+	if ph.tag != "" {
+		lines = append(lines, ph.tag)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var warningReadings = map[string]string{
+	"大雨":   "おおあめ",
+	"洪水":   "こうずい",
+	"暴風":   "ぼうふう",
+	"強風":   "きょうふう",
+	"大雪":   "おおゆき",
+	"風雪":   "ふうせつ",
+	"暴風雪":  "ぼうふうせつ",
+	"波浪":   "はろう",
+	"高潮":   "たかしお",
+	"雷":    "かみなり",
+	"融雪":   "ゆうせつ",
+	"濃霧":   "のうむ",
+	"乾燥":   "かんそう",
+	"なだれ":  "なだれ",
+	"低温":   "ていおん",
+	"霜":    "しも",
+	"着氷":   "ちゃくひょう",
+	"着雪":   "ちゃくせつ",
+	"特別警報": "とくべつけいほう",
+	"警報":   "けいほう",
+	"注意報":  "ちゅういほう",
+	"発表":   "はっぴょう",
+	"切替":   "きりかえ",
+	"解除":   "かいじょ",
+}
+
+// warningReading returns the reading of a warning name,
+// since the font of genpng does not have most of the kanji in it.
+func warningReading(name string) string {
+	phenomenon := warningPhenomenon(name)
+	reading, ok := warningReadings[phenomenon]
+	if !ok {
+		reading = phenomenon
+	}
+	suffix := strings.TrimPrefix(name, phenomenon)
+	if r, ok := warningReadings[suffix]; ok {
+		suffix = r
+	}
+	return reading + suffix
+}
+
+func genWarningBanner(notices []WarningNotice) genpng.WarningBanner {
+	banner := genpng.WarningBanner{
+		Title: "けいほう ちゅういほう",
+	}
+	for _, n := range notices {
+		item := genpng.WarningBannerItem{
+			Text:  fmt.Sprintf("%s %s", warningReading(n.Name), warningReadings[n.Status]),
+			Level: warningLevel(n.Name),
+		}
+		if n.Status == warningCancelled {
+			item.Level = 0
+		}
+		banner.Items = append(banner.Items, item)
+	}
+	return banner
+}
+
+func loadWarningState(store Store) (*WarningState, error) {
+	var state WarningState
+	data, err := store.Get(warningStateKey)
+	if err == errStoreNotFound {
+		return &state, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load warning state")
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrap(err, "failed to decode warning state")
+	}
+	return &state, nil
+}
+
+func saveWarningState(store Store, state *WarningState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return store.Put(warningStateKey, "application/json", data)
+}
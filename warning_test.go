@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bamchoh/bam-weather/dialect"
+)
+
+func TestWarningText(t *testing.T) {
+	region := regions["osaka"]
+	notices := []WarningNotice{
+		{Name: "大雨警報", From: "大雨注意報", Status: warningSwitched},
+		{Name: "雷注意報", Status: warningIssued},
+		{Name: "強風注意報", Status: warningCancelled},
+	}
+	tests := []struct {
+		channel Channel
+		want    string
+	}{
+		{
+			Channel{Type: channelTwitter, Persona: dialect.Osaka},
+			"大阪の警報・注意報やで\n大雨注意報が大雨警報に上がったで！\n雷注意報が出たで！\n強風注意報は解除されたで\n#bam_weather",
+		},
+		{
+			Channel{Type: channelSlack, Persona: dialect.Keigo},
+			"大阪の警報・注意報をお知らせします\n大雨注意報が大雨警報に引き上げられました\n雷注意報が発表されました\n強風注意報は解除されました",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.channel.warningText(region, notices); got != tt.want {
+			t.Errorf("%s: warningText() = %q, want %q", tt.channel.Persona, got, tt.want)
+		}
+	}
+
+	c := Channel{Type: channelMastodon, Language: "en"}
+	want := "Warnings for Osaka\nheavy rain advisory upgraded to heavy rain warning!\nthunder advisory issued!\ngale advisory lifted\n#bam_weather"
+	if got := c.warningText(region, notices); got != want {
+		t.Errorf("en: warningText() = %q, want %q", got, want)
+	}
+}
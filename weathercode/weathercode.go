@@ -0,0 +1,174 @@
+package weathercode
+
+// Icons which genpng can draw.
+const (
+	Sunny      = "晴れ"
+	Cloudy     = "くもり"
+	Rain       = "雨"
+	Snow       = "雪"
+	Thunder    = "雷"
+	Fog        = "霧"
+	Sleet      = "みぞれ"
+	RainOrSnow = "雨か雪"
+	Storm      = "暴風"
+)
+
+// Connectors between the primary and secondary weathers.
+const (
+	Sometimes = "時々"
+	After     = "のち"
+	Temporary = "一時"
+	Or        = "か"
+)
+
+// Telop is a weather of 天気予報用テロップ番号 (WeatherCode).
+// Primary and Secondary are icons, Secondary and Connector are empty
+// for a single weather. Text is the normalized weather.
+type Telop struct {
+	Code      string
+	Text      string
+	Primary   string
+	Connector string
+	Secondary string
+}
+
+// Lookup returns the telop of code.
+func Lookup(code string) (Telop, bool) {
+	t, ok := telopMap[code]
+	return t, ok
+}
+
+var telopMap = func() map[string]Telop {
+	m := map[string]Telop{}
+	for _, t := range telops {
+		m[t.Code] = t
+	}
+	return m
+}()
+
+// telops is the table of JMA weather codes.
+// Snow or rain is drawn as rain or snow.
+var telops = []Telop{
+	{"100", "晴れ", Sunny, "", ""},
+	{"101", "晴れ時々くもり", Sunny, Sometimes, Cloudy},
+	{"102", "晴れ一時雨", Sunny, Temporary, Rain},
+	{"103", "晴れ時々雨", Sunny, Sometimes, Rain},
+	{"104", "晴れ一時雪", Sunny, Temporary, Snow},
+	{"105", "晴れ時々雪", Sunny, Sometimes, Snow},
+	{"106", "晴れ一時雨か雪", Sunny, Temporary, RainOrSnow},
+	{"107", "晴れ時々雨か雪", Sunny, Sometimes, RainOrSnow},
+	{"108", "晴れ一時雨か雷雨", Sunny, Temporary, Thunder},
+	{"110", "晴れのち時々くもり", Sunny, After, Cloudy},
+	{"111", "晴れのちくもり", Sunny, After, Cloudy},
+	{"112", "晴れのち一時雨", Sunny, After, Rain},
+	{"113", "晴れのち時々雨", Sunny, After, Rain},
+	{"114", "晴れのち雨", Sunny, After, Rain},
+	{"115", "晴れのち一時雪", Sunny, After, Snow},
+	{"116", "晴れのち時々雪", Sunny, After, Snow},
+	{"117", "晴れのち雪", Sunny, After, Snow},
+	{"118", "晴れのち雨か雪", Sunny, After, RainOrSnow},
+	{"119", "晴れのち雨か雷雨", Sunny, After, Thunder},
+	{"120", "晴れ朝夕一時雨", Sunny, Temporary, Rain},
+	{"121", "晴れ朝の内一時雨", Sunny, Temporary, Rain},
+	{"122", "晴れ夕方一時雨", Sunny, Temporary, Rain},
+	{"123", "晴れ山沿い雷雨", Sunny, Temporary, Thunder},
+	{"124", "晴れ山沿い雪", Sunny, Temporary, Snow},
+	{"125", "晴れ午後は雷雨", Sunny, After, Thunder},
+	{"126", "晴れ昼頃から雨", Sunny, After, Rain},
+	{"127", "晴れ夕方から雨", Sunny, After, Rain},
+	{"128", "晴れ夜は雨", Sunny, After, Rain},
+	{"130", "朝の内霧のち晴れ", Fog, After, Sunny},
+	{"131", "晴れ明け方霧", Sunny, Temporary, Fog},
+	{"132", "晴れ朝夕くもり", Sunny, Sometimes, Cloudy},
+	{"140", "晴れ時々雨で雷を伴う", Sunny, Sometimes, Thunder},
+	{"160", "晴れ一時雪か雨", Sunny, Temporary, RainOrSnow},
+	{"170", "晴れ時々雪か雨", Sunny, Sometimes, RainOrSnow},
+	{"181", "晴れのち雪か雨", Sunny, After, RainOrSnow},
+
+	{"200", "くもり", Cloudy, "", ""},
+	{"201", "くもり時々晴れ", Cloudy, Sometimes, Sunny},
+	{"202", "くもり一時雨", Cloudy, Temporary, Rain},
+	{"203", "くもり時々雨", Cloudy, Sometimes, Rain},
+	{"204", "くもり一時雪", Cloudy, Temporary, Snow},
+	{"205", "くもり時々雪", Cloudy, Sometimes, Snow},
+	{"206", "くもり一時雨か雪", Cloudy, Temporary, RainOrSnow},
+	{"207", "くもり時々雨か雪", Cloudy, Sometimes, RainOrSnow},
+	{"208", "くもり一時雨か雷雨", Cloudy, Temporary, Thunder},
+	{"209", "霧", Fog, "", ""},
+	{"210", "くもりのち時々晴れ", Cloudy, After, Sunny},
+	{"211", "くもりのち晴れ", Cloudy, After, Sunny},
+	{"212", "くもりのち一時雨", Cloudy, After, Rain},
+	{"213", "くもりのち時々雨", Cloudy, After, Rain},
+	{"214", "くもりのち雨", Cloudy, After, Rain},
+	{"215", "くもりのち一時雪", Cloudy, After, Snow},
+	{"216", "くもりのち時々雪", Cloudy, After, Snow},
+	{"217", "くもりのち雪", Cloudy, After, Snow},
+	{"218", "くもりのち雨か雪", Cloudy, After, RainOrSnow},
+	{"219", "くもりのち雨か雷雨", Cloudy, After, Thunder},
+	{"220", "くもり朝夕一時雨", Cloudy, Temporary, Rain},
+	{"221", "くもり朝の内一時雨", Cloudy, Temporary, Rain},
+	{"222", "くもり夕方一時雨", Cloudy, Temporary, Rain},
+	{"223", "くもり日中時々晴れ", Cloudy, Sometimes, Sunny},
+	{"224", "くもり昼頃から雨", Cloudy, After, Rain},
+	{"225", "くもり夕方から雨", Cloudy, After, Rain},
+	{"226", "くもり夜は雨", Cloudy, After, Rain},
+	{"228", "くもり昼頃から雪", Cloudy, After, Snow},
+	{"229", "くもり夕方から雪", Cloudy, After, Snow},
+	{"230", "くもり夜は雪", Cloudy, After, Snow},
+	{"231", "くもり海上海岸は霧か霧雨", Cloudy, Temporary, Fog},
+	{"240", "くもり時々雨で雷を伴う", Cloudy, Sometimes, Thunder},
+	{"250", "くもり時々雪で雷を伴う", Cloudy, Sometimes, Thunder},
+	{"260", "くもり一時雪か雨", Cloudy, Temporary, RainOrSnow},
+	{"270", "くもり時々雪か雨", Cloudy, Sometimes, RainOrSnow},
+	{"281", "くもりのち雪か雨", Cloudy, After, RainOrSnow},
+
+	{"300", "雨", Rain, "", ""},
+	{"301", "雨時々晴れ", Rain, Sometimes, Sunny},
+	{"302", "雨時々止む", Rain, Sometimes, Cloudy},
+	{"303", "雨時々雪", Rain, Sometimes, Snow},
+	{"304", "雨か雪", RainOrSnow, "", ""},
+	{"306", "大雨", Rain, "", ""},
+	{"307", "風雨共に強い", Storm, "", ""},
+	{"308", "雨で暴風を伴う", Storm, "", ""},
+	{"309", "雨一時雪", Rain, Temporary, Snow},
+	{"311", "雨のち晴れ", Rain, After, Sunny},
+	{"313", "雨のちくもり", Rain, After, Cloudy},
+	{"314", "雨のち時々雪", Rain, After, Snow},
+	{"315", "雨のち雪", Rain, After, Snow},
+	{"316", "雨か雪のち晴れ", RainOrSnow, After, Sunny},
+	{"317", "雨か雪のちくもり", RainOrSnow, After, Cloudy},
+	{"320", "朝の内雨のち晴れ", Rain, After, Sunny},
+	{"321", "朝の内雨のちくもり", Rain, After, Cloudy},
+	{"322", "雨朝晩一時雪", Rain, Temporary, Snow},
+	{"323", "雨昼頃から晴れ", Rain, After, Sunny},
+	{"324", "雨夕方から晴れ", Rain, After, Sunny},
+	{"325", "雨夜は晴れ", Rain, After, Sunny},
+	{"326", "雨夕方から雪", Rain, After, Snow},
+	{"327", "雨夜は雪", Rain, After, Snow},
+	{"328", "雨一時強く降る", Rain, "", ""},
+	{"329", "雨一時みぞれ", Rain, Temporary, Sleet},
+	{"340", "雪か雨", RainOrSnow, "", ""},
+	{"350", "雨で雷を伴う", Thunder, "", ""},
+	{"361", "雪か雨のち晴れ", RainOrSnow, After, Sunny},
+	{"371", "雪か雨のちくもり", RainOrSnow, After, Cloudy},
+
+	{"400", "雪", Snow, "", ""},
+	{"401", "雪時々晴れ", Snow, Sometimes, Sunny},
+	{"402", "雪時々止む", Snow, Sometimes, Cloudy},
+	{"403", "雪時々雨", Snow, Sometimes, Rain},
+	{"405", "大雪", Snow, "", ""},
+	{"406", "風雪強い", Storm, "", ""},
+	{"407", "暴風雪", Storm, "", ""},
+	{"409", "雪一時雨", Snow, Temporary, Rain},
+	{"411", "雪のち晴れ", Snow, After, Sunny},
+	{"413", "雪のちくもり", Snow, After, Cloudy},
+	{"414", "雪のち雨", Snow, After, Rain},
+	{"420", "朝の内雪のち晴れ", Snow, After, Sunny},
+	{"421", "朝の内雪のちくもり", Snow, After, Cloudy},
+	{"422", "雪昼頃から雨", Snow, After, Rain},
+	{"423", "雪夕方から雨", Snow, After, Rain},
+	{"425", "雪一時強く降る", Snow, "", ""},
+	{"426", "雪のちみぞれ", Snow, After, Sleet},
+	{"427", "雪一時みぞれ", Snow, Temporary, Sleet},
+	{"450", "雪で雷を伴う", Snow, Sometimes, Thunder},
+}
@@ -0,0 +1,72 @@
+package weathercode
+
+import (
+	"strings"
+	"testing"
+)
+
+// jmaCodes are the weather codes which JMA publishes.
+var jmaCodes = strings.Fields(`
+	100 101 102 103 104 105 106 107 108 110 111 112 113 114 115 116 117 118 119 120
+	121 122 123 124 125 126 127 128 130 131 132 140 160 170 181
+	200 201 202 203 204 205 206 207 208 209 210 211 212 213 214 215 216 217 218 219
+	220 221 222 223 224 225 226 228 229 230 231 240 250 260 270 281
+	300 301 302 303 304 306 307 308 309 311 313 314 315 316 317 320 321 322 323 324
+	325 326 327 328 329 340 350 361 371
+	400 401 402 403 405 406 407 409 411 413 414 420 421 422 423 425 426 427 450
+`)
+
+func TestTelopsComplete(t *testing.T) {
+	for _, code := range jmaCodes {
+		if _, ok := Lookup(code); !ok {
+			t.Errorf("code %s is missing", code)
+		}
+	}
+	if len(telops) != len(jmaCodes) {
+		t.Errorf("telops has %d codes, want %d", len(telops), len(jmaCodes))
+	}
+	if len(telopMap) != len(telops) {
+		t.Errorf("telops has %d duplicated codes", len(telops)-len(telopMap))
+	}
+}
+
+func TestTelops(t *testing.T) {
+	icons := map[string]bool{
+		Sunny: true, Cloudy: true, Rain: true, Snow: true, Thunder: true,
+		Fog: true, Sleet: true, RainOrSnow: true, Storm: true,
+	}
+	connectors := map[string]bool{"": true, Sometimes: true, After: true, Temporary: true}
+	for _, tt := range telops {
+		if tt.Text == "" || !icons[tt.Primary] {
+			t.Errorf("%s: text %q, primary %q", tt.Code, tt.Text, tt.Primary)
+		}
+		if !connectors[tt.Connector] {
+			t.Errorf("%s: connector %q", tt.Code, tt.Connector)
+		}
+		if (tt.Connector == "") != (tt.Secondary == "") || (tt.Secondary != "" && !icons[tt.Secondary]) {
+			t.Errorf("%s: connector %q, secondary %q", tt.Code, tt.Connector, tt.Secondary)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		code string
+		want Telop
+		ok   bool
+	}{
+		{"100", Telop{"100", "晴れ", Sunny, "", ""}, true},
+		{"202", Telop{"202", "くもり一時雨", Cloudy, Temporary, Rain}, true},
+		{"240", Telop{"240", "くもり時々雨で雷を伴う", Cloudy, Sometimes, Thunder}, true},
+		{"313", Telop{"313", "雨のちくもり", Rain, After, Cloudy}, true},
+		{"450", Telop{"450", "雪で雷を伴う", Snow, Sometimes, Thunder}, true},
+		{"999", Telop{}, false},
+		{"", Telop{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := Lookup(tt.code)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Lookup(%q) = %+v, %v, want %+v, %v", tt.code, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/bamchoh/bam-weather/weathercode"
+)
+
+type WeeklyReport struct {
+	Control Control
+	Head    Head
+	Body    WeeklyBody
+}
+
+type WeeklyBody struct {
+	MeteorologicalInfos []WeeklyInfo `xml:"MeteorologicalInfos"`
+}
+
+type WeeklyInfo struct {
+	Type        string             `xml:"type,attr"`
+	TimeDefines []WeeklyTimeDefine `xml:"TimeSeriesInfo>TimeDefines>TimeDefine"`
+	Items       []WeeklyItem       `xml:"TimeSeriesInfo>Item"`
+}
+
+type WeeklyTimeDefine struct {
+	ID       string `xml:"timeId,attr"`
+	DateTime string `xml:"DateTime"`
+}
+
+type WeeklyItem struct {
+	Kinds   []WeeklyProperty `xml:"Kind>Property"`
+	Area    Area
+	Station Station
+}
+
+type WeeklyProperty struct {
+	Type          string
+	Weathers      []RefValue    `xml:"WeatherPart>Weather"`
+	WeatherCodes  []RefValue    `xml:"WeatherCodePart>WeatherCode"`
+	POPs          []RefValue    `xml:"ProbabilityOfPrecipitationPart>ProbabilityOfPrecipitation"`
+	Reliabilities []RefValue    `xml:"ReliabilityClassPart>ReliabilityClass"`
+	Temperatures  []Temperature `xml:"TemperaturePart>Temperature"`
+}
+
+// RefValue is an element of JMA XML which refers to a time define.
+type RefValue struct {
+	ID    string `xml:"refID,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WeeklyDay is the forecast of a day in 府県週間天気予報.
+// POP is -1 and temperatures are missing when they are not forecasted.
+type WeeklyDay struct {
+	Date        time.Time
+	Weather     string
+	WeatherCode string
+	POP         int
+	TempL       temp.Temp
+	TempH       temp.Temp
+	Reliability string
+}
+
+func findRefValue(values []RefValue, refID string) string {
+	for _, v := range values {
+		if v.ID == refID {
+			return strings.TrimSpace(v.Value)
+		}
+	}
+	return ""
+}
+
+func findWeeklyTemperature(temps []Temperature, refID string) (temp.Temp, error) {
+	for _, t := range temps {
+		if t.ID == refID {
+			return t.Value()
+		}
+	}
+	return temp.Temp{}, nil
+}
+
+func newWeeklyDays(v *WeeklyReport, region Region) ([]WeeklyDay, error) {
+	var days []WeeklyDay
+	for _, info := range v.Body.MeteorologicalInfos {
+		if info.Type != "区域予報" {
+			continue
+		}
+		var item *WeeklyItem
+		for i := range info.Items {
+			if info.Items[i].Area.Code == region.AreaCode {
+				item = &info.Items[i]
+				break
+			}
+		}
+		if item == nil {
+			return nil, fmt.Errorf("area (%v) was not found", region.AreaCode)
+		}
+
+		for _, def := range info.TimeDefines {
+			tt, err := time.Parse(time.RFC3339, def.DateTime)
+			if err != nil {
+				return nil, err
+			}
+			day := WeeklyDay{Date: tt, POP: -1}
+			for _, kind := range item.Kinds {
+				switch kind.Type {
+				case "天気":
+					day.Weather = findRefValue(kind.Weathers, def.ID)
+					day.WeatherCode = findRefValue(kind.WeatherCodes, def.ID)
+				case "降水確率":
+					if pop, err := strconv.Atoi(findRefValue(kind.POPs, def.ID)); err == nil {
+						day.POP = pop
+					}
+				case "信頼度":
+					day.Reliability = findRefValue(kind.Reliabilities, def.ID)
+				}
+			}
+			days = append(days, day)
+		}
+		break
+	}
+
+	for _, info := range v.Body.MeteorologicalInfos {
+		if info.Type != "地点予報" {
+			continue
+		}
+		var item *WeeklyItem
+		for i := range info.Items {
+			if info.Items[i].Station.Name == region.Station {
+				item = &info.Items[i]
+				break
+			}
+		}
+		if item == nil {
+			return nil, fmt.Errorf("station (%v) was not found", region.Station)
+		}
+
+		for _, def := range info.TimeDefines {
+			tt, err := time.Parse(time.RFC3339, def.DateTime)
+			if err != nil {
+				return nil, err
+			}
+			for i := range days {
+				if !days[i].Date.Equal(tt) {
+					continue
+				}
+				for _, kind := range item.Kinds {
+					var err error
+					switch kind.Type {
+					case "最低気温":
+						days[i].TempL, err = findWeeklyTemperature(kind.Temperatures, def.ID)
+					case "最高気温":
+						days[i].TempH, err = findWeeklyTemperature(kind.Temperatures, def.ID)
+					}
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+		break
+	}
+	return days, nil
+}
+
+// weeklyIcon returns the icon of day by the weather code,
+// or by the weather text when the code is unknown.
+func weeklyIcon(day WeeklyDay) string {
+	if t, ok := weathercode.Lookup(day.WeatherCode); ok {
+		return t.Primary
+	}
+	return primaryWeather(day.Weather)
+}
+
+// primaryWeather returns the first weather in text which genpng can draw.
+func primaryWeather(text string) string {
+	first := ""
+	pos := len(text)
+	for _, w := range []string{"晴れ", "くもり", "雨", "雪", "雷"} {
+		if i := strings.Index(text, w); i >= 0 && i < pos {
+			first = w
+			pos = i
+		}
+	}
+	return first
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bamchoh/bam-weather/dialect"
+	"github.com/bamchoh/bam-weather/temp"
+)
+
+func TestWeeklyText(t *testing.T) {
+	days := []WeeklyDay{
+		{Date: time.Date(2020, 9, 10, 0, 0, 0, 0, time.Local), Weather: "晴れ時々くもり", WeatherCode: "101", POP: -1,
+			TempL: temp.New(22, temp.Celsius), TempH: temp.New(31, temp.Celsius)},
+		{Date: time.Date(2020, 9, 11, 0, 0, 0, 0, time.Local), Weather: "雨", WeatherCode: "300", POP: 80,
+			TempL: temp.New(21, temp.Celsius), TempH: temp.New(26, temp.Celsius)},
+	}
+	tests := []struct {
+		channel Channel
+		want    string
+	}{
+		{
+			Channel{Type: channelTwitter, Persona: dialect.Osaka},
+			"大阪の週間天気やで\n10日(木) ☀たま～に☁ 22/31\n11日(金) ☔ 80% 21/26\n#bam_weather",
+		},
+		{
+			Channel{Type: channelSlack, Persona: dialect.Keigo},
+			"大阪の週間天気予報をお知らせします\n10日(木) 晴れ時々くもり 22/31\n11日(金) 雨 80% 21/26",
+		},
+		{
+			Channel{Type: channelMastodon, Language: "en"},
+			"Weekly weather for Osaka\nThu, Sep 10 sunny, at times cloudy 22/31\nFri, Sep 11 rain 80% 21/26\n#bam_weather",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.channel.weeklyText(regions["osaka"], days, temp.Celsius); got != tt.want {
+			t.Errorf("%s%s: weeklyText() = %q, want %q", tt.channel.Persona, tt.channel.Language, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bamchoh/bam-weather/genpng"
+	"github.com/bamchoh/bam-weather/temp"
+	"github.com/pkg/errors"
+)
+
+type WeeklyWeatherGenerator struct {
+	BaseTime time.Time
+	Source   ForecastSource
+	Region   Region
+	Options  Options
+	days     []WeeklyDay
+}
+
+func (gen *WeeklyWeatherGenerator) Init(ctx context.Context) error {
+	bt := gen.BaseTime
+	ref, err := findReport(ctx, gen.Source, "府県週間天気予報", bt.Add(-12*time.Hour), bt)
+	if err != nil {
+		err = errors.Wrap(err, "failed to find weekly report")
+		log.Println(err)
+		return err
+	}
+
+	var v WeeklyReport
+	if err := gen.Source.FetchReport(ctx, ref.Link, &v); err != nil {
+		err = errors.Wrap(err, "failed to fetch weekly report")
+		log.Println(err)
+		return err
+	}
+
+	gen.days, err = newWeeklyDays(&v, gen.Region)
+	if err != nil {
+		err = errors.Wrap(err, "failed to get weekly info")
+		log.Println(err)
+		return err
+	}
+	if len(gen.days) == 0 {
+		err = errors.New("weekly forecast is empty")
+		log.Println(err)
+		return err
+	}
+	return nil
+}
+
+// Days returns the days of the weekly forecast.
+func (gen *WeeklyWeatherGenerator) Days() []WeeklyDay {
+	return gen.days
+}
+
+// weeklyText returns the text of the weekly forecast for the channel,
+// a line for each day. Weathers are translated for a channel with
+// a language, and told by the weather code when they cannot be.
+func (c Channel) weeklyText(region Region, days []WeeklyDay, unit temp.Unit) string {
+	wdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	label := func(t time.Time) string {
+		return fmt.Sprintf("%d日(%s)", t.Day(), wdays[t.Weekday()])
+	}
+	var intro, tag string
+	var weather func(day WeeklyDay) string
+	if l, ok, _ := lookupLang(c.Language); ok {
+		region.Name = region.NameIn(l.Code)
+		intro, tag = l.Phrases.WeeklyIntro, l.Phrases.Tag
+		label = l.Day
+		weather = func(day WeeklyDay) string {
+			if s, ok := l.Translate(day.Weather); ok {
+				return s
+			}
+			return translateWeatherCode(day.WeatherCode, l)
+		}
+	} else {
+		p := personas[c.Persona]
+		ph := c.phrases()
+		intro, tag = ph.WeeklyIntro, ph.Tag
+		weather = func(day WeeklyDay) string {
+			return p.Apply(day.Weather)
+		}
+	}
+
+	lines := []string{fmt.Sprintf(intro, region.Name)}
+	for _, day := range days {
+		line := label(day.Date) + " " + weather(day)
+		if day.POP >= 0 {
+			line += fmt.Sprintf(" %d%%", day.POP)
+		}
+		if day.TempL.Valid() || day.TempH.Valid() {
+			line += fmt.Sprintf(" %s/%s", day.TempL.Format(unit), day.TempH.Format(unit))
+		}
+		lines = append(lines, line)
+	}
+	if tag != "" {
+		lines = append(lines, tag)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (gen *WeeklyWeatherGenerator) WeeklyDays() []genpng.WeeklyDay {
+	wdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	var days []genpng.WeeklyDay
+	if l, ok, _ := lookupLang(gen.Options.Language); ok {
+		wdays = imageLang(l).Weekdays[:]
+	}
+	for _, day := range gen.days {
+		days = append(days, genpng.WeeklyDay{
+			Label:       fmt.Sprintf("%d %s", day.Date.Day(), wdays[day.Date.Weekday()]),
+			Weather:     weeklyIcon(day),
+			POP:         day.POP,
+			Low:         day.TempL,
+			High:        day.TempH,
+			Unit:        gen.Options.Unit(),
+			Reliability: day.Reliability,
+		})
+	}
+	return days
+}
+
+func (gen *WeeklyWeatherGenerator) Day() time.Time {
+	return gen.days[0].Date
+}